@@ -2,6 +2,7 @@ package arista
 
 import (
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -9,27 +10,57 @@ import (
 )
 
 var patternISIS = regexp.MustCompile(`^L2 Neighbor State Change .+ SystemID (?P<remote>\S+) on (?P<iface>\S+).*to (?P<state>\S+)(: (?P<reason>.+))?$`)
-var patternBGP = regexp.MustCompile(`^peer (?P<remote>\S+) \(VRF (?P<table>\S+) AS (?P<remote_as>\S+)\) old .+ new state (?P<state>\S+)$`)
+var patternBGP = regexp.MustCompile(`^peer (?P<remote>\S+) \(VRF (?P<table>\S+) AS (?P<remote_as>\S+)\) old state (?P<old_state>\S+)(?: event (?P<event>\S+))? new state (?P<state>\S+)$`)
+var patternBFD = regexp.MustCompile(`%BFD-5-STATE_CHANGE: peer \(vrf:(?P<table>[^,]+), ip:(?P<remote>[^,]+), intf:(?P<iface>[^,\)]+)(?:, [^\)]*)?\) changed state from (?P<old_state>\S+) to (?P<state>\S+)(?: diag (?P<diag>\S+))?.*$`)
+var patternInterface = regexp.MustCompile(`%(?:LINEPROTO-5-UPDOWN: Line protocol on |LINK-3-UPDOWN: |ETH-4-INTF_\w+: )Interface (?P<iface>[^\s,]+)(?: \([^\)]*\))?,? changed state to (?P<state>(?:administratively )?\w+).*$`)
+var patternLACP = regexp.MustCompile(`%LACP-4-\w+: (?P<reason>.*?(?P<iface>Ethernet[\d/]+)(?:.*?(?P<parent>Port-Channel\d+))?.*)$`)
+var patternNotification = regexp.MustCompile(`%BGP-3-NOTIFICATION: (?P<direction>sent to|received from) neighbor (?P<remote>\S+) \(VRF (?P<table>\S+) AS (?P<remote_as>\d+)\) (?P<code>\d+)/(?P<subcode>\d+) \((?P<text>[^\)]+)\).*$`)
+var patternPrefixLimit = regexp.MustCompile(`%BGP-3-MAXPFX: [Pp]eer (?P<remote>\S+) \(VRF (?P<table>\S+) AS (?P<remote_as>\d+)\) (?P<afi_safi>.+?) prefix limit (?P<limit>\d+) (?P<kind>reached|exceeded), received (?P<count>\d+) prefixes(?P<teardown>, session (?:torn down|reset))?.*$`)
+var patternOSPF = regexp.MustCompile(`%OSPF(?P<version>3)?-4-OSPF3?_ADJACENCY_(?P<event>ESTABLISHED|TEARDOWN): NGB (?P<remote>[^,\s]+),(?: instance \S+,)?(?: VRF \S+,)? interface (?P<iface_addr>[^\s,]+)(?: \((?P<iface>[^\)]+)\))? adjacency (?:established|dropped)(?:: (?P<reason>.+?))?(?:, state was: (?P<old_state>\S+))?$`)
 
 const (
-	bgpLen     int = 5
+	bgpLen     int = 7
 	isisMinLen int = 5
 	isisMaxLen int = 6
+	ospfLen    int = 8
+	bfdLen     int = 7
+	ifaceLen   int = 3
+	lacpLen    int = 4
+	notifyLen  int = 8
+	prefixLen  int = 9
 )
 
-type Parser func(string, string, time.Time, map[string]any) (types.Log, error)
+const received string = "received from"
 
-var parseMap = map[*regexp.Regexp]Parser{
-	regexp.MustCompile(`^L[12] Neighbor.+`):      ParseISIS,
-	regexp.MustCompile(`^peer [0-9a-f\.\:]+.*$`): ParseBGP,
-}
+// A prefix limit that is reached, rather than exceeded, is only a warning.
+const prefixThreshold string = "reached"
+
+const (
+	ospfEstablished string = "ESTABLISHED"
+	ospfFull        string = "FULL"
+	ospfDown        string = "DOWN"
+)
+
+type Parser = types.MessageParser
+
+// Patterns are evaluated in order against each message, and may be extended or overridden.
+var Patterns = types.NewPatterns(
+	types.Pattern{Name: "isis", Match: types.RegexpMatcher(regexp.MustCompile(`^L[12] Neighbor.+`)), Parse: ParseISIS, Regexp: patternISIS, StopOnMatch: true},
+	types.Pattern{Name: "bgp", Match: types.RegexpMatcher(regexp.MustCompile(`^peer [0-9a-f\.\:]+.*$`)), Parse: ParseBGP, Regexp: patternBGP, StopOnMatch: true},
+	types.Pattern{Name: "ospf", Match: types.RegexpMatcher(regexp.MustCompile(`%OSPF3?-4-OSPF3?_ADJACENCY_`)), Parse: ParseOSPF, Regexp: patternOSPF, StopOnMatch: true},
+	types.Pattern{Name: "bfd", Match: types.RegexpMatcher(regexp.MustCompile(`%BFD-5-STATE_CHANGE`)), Parse: ParseBFD, Regexp: patternBFD, StopOnMatch: true},
+	types.Pattern{Name: "interface", Match: types.RegexpMatcher(regexp.MustCompile(`%(LINEPROTO-5-UPDOWN|LINK-3-UPDOWN|ETH-4-INTF_\w+):`)), Parse: ParseInterface, Regexp: patternInterface, StopOnMatch: true},
+	types.Pattern{Name: "bgp_notification", Match: types.RegexpMatcher(regexp.MustCompile(`%BGP-3-NOTIFICATION`)), Parse: ParseNotification, Regexp: patternNotification, StopOnMatch: true},
+	types.Pattern{Name: "bgp_prefix_limit", Match: types.RegexpMatcher(regexp.MustCompile(`%BGP-3-MAXPFX`)), Parse: ParsePrefixLimit, Regexp: patternPrefixLimit, StopOnMatch: true},
+	types.Pattern{Name: "lacp", Match: types.RegexpMatcher(regexp.MustCompile(`%LACP-4-\w+:`)), Parse: ParseLACP, Regexp: patternLACP, StopOnMatch: true},
+)
 
 func ParseISIS(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
 	names := patternISIS.SubexpNames()
 	matches := patternISIS.FindStringSubmatch(msg)
 
 	if len(matches) < isisMinLen || len(matches) > isisMaxLen {
-		return nil, types.ErrIncompleteMatch
+		return nil, types.IncompleteMatchErr(patternISIS, matches)
 	}
 
 	iRemote := patternISIS.SubexpIndex(names[1])
@@ -69,51 +100,274 @@ func ParseBGP(msg, src string, ts time.Time, extra map[string]any) (types.Log, e
 	names := patternBGP.SubexpNames()
 	matches := patternBGP.FindStringSubmatch(msg)
 	if len(matches) != bgpLen {
-		return nil, types.ErrIncompleteMatch
+		return nil, types.IncompleteMatchErr(patternBGP, matches)
 	}
 
 	iRemote := patternBGP.SubexpIndex(names[1])
 	iTable := patternBGP.SubexpIndex(names[2])
 	iASN := patternBGP.SubexpIndex(names[3])
-	iState := patternBGP.SubexpIndex(names[4])
+	iOldState := patternBGP.SubexpIndex(names[4])
+	iEvent := patternBGP.SubexpIndex(names[5])
+	iState := patternBGP.SubexpIndex(names[6])
 
 	remote := strings.TrimSpace(matches[iRemote])
 	asn := strings.TrimSpace(matches[iASN])
+	oldState := strings.TrimSpace(matches[iOldState])
+	event := strings.TrimSpace(matches[iEvent])
 	state := strings.TrimSpace(matches[iState])
 	table := strings.TrimSpace(matches[iTable])
 
 	l := &types.BGPLog{
-		Base:      types.Base{Type: types.BGP, Original: msg, Extra: extra},
-		Timestamp: ts,
+		Base:             types.Base{Type: types.BGP, Original: msg, Extra: extra},
+		Timestamp:        ts,
+		Local:            src,
+		Remote:           remote,
+		State:            types.DOWN,
+		RemoteAS:         asn,
+		Table:            table,
+		FSMState:         types.ParseBGPState(state),
+		PreviousFSMState: types.ParseBGPState(oldState),
+		Event:            event,
+	}
+	if strings.Contains(strings.ToLower(state), "established") {
+		l.State = types.UP
+	}
+	return l, nil
+}
+
+func ParseOSPF(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	names := patternOSPF.SubexpNames()
+	matches := patternOSPF.FindStringSubmatch(msg)
+	if len(matches) != ospfLen {
+		return nil, types.IncompleteMatchErr(patternOSPF, matches)
+	}
+
+	iVersion := patternOSPF.SubexpIndex(names[1])
+	iEvent := patternOSPF.SubexpIndex(names[2])
+	iRemote := patternOSPF.SubexpIndex(names[3])
+	iIfAddr := patternOSPF.SubexpIndex(names[4])
+	iIf := patternOSPF.SubexpIndex(names[5])
+	iReason := patternOSPF.SubexpIndex(names[6])
+	iOldState := patternOSPF.SubexpIndex(names[7])
+
+	event := strings.TrimSpace(matches[iEvent])
+	remote := strings.TrimSpace(matches[iRemote])
+	iface := strings.TrimSpace(matches[iIf])
+	reason := strings.TrimSpace(matches[iReason])
+	oldState := strings.TrimSpace(matches[iOldState])
+
+	if iface == "" {
+		iface = strings.TrimSpace(matches[iIfAddr])
+	}
+
+	l := &types.OSPFLog{
+		Base:      types.Base{Type: types.OSPF, Original: msg, Extra: extra},
 		Local:     src,
+		Timestamp: ts,
 		Remote:    remote,
+		Interface: iface,
+		OldState:  oldState,
+		NewState:  ospfDown,
+		Reason:    reason,
 		State:     types.DOWN,
+		Version:   2,
+	}
+	if matches[iVersion] != "" {
+		l.Version = 3
+	}
+	if event == ospfEstablished {
+		l.State = types.UP
+		l.NewState = ospfFull
+	}
+	return l, nil
+}
+
+func ParseBFD(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	names := patternBFD.SubexpNames()
+	matches := patternBFD.FindStringSubmatch(msg)
+	if len(matches) != bfdLen {
+		return nil, types.IncompleteMatchErr(patternBFD, matches)
+	}
+
+	iRemote := patternBFD.SubexpIndex(names[2])
+	iIf := patternBFD.SubexpIndex(names[3])
+	iState := patternBFD.SubexpIndex(names[5])
+	iDiag := patternBFD.SubexpIndex(names[6])
+
+	remote := strings.TrimSpace(matches[iRemote])
+	iface := strings.TrimSpace(matches[iIf])
+	state := strings.TrimSpace(matches[iState])
+	diag := strings.TrimSpace(matches[iDiag])
+
+	if strings.Contains(strings.ToLower(state), "init") {
+		return nil, nil
+	}
+
+	l := &types.BFDLog{
+		Base:       types.Base{Type: types.BFD, Original: msg, Extra: extra},
+		Local:      src,
+		Timestamp:  ts,
+		Remote:     remote,
+		Interface:  iface,
+		Diagnostic: diag,
+		State:      types.DOWN,
+	}
+	if strings.ToLower(state) == "up" {
+		l.State = types.UP
+	}
+	return l, nil
+}
+
+func ParseInterface(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	names := patternInterface.SubexpNames()
+	matches := patternInterface.FindStringSubmatch(msg)
+	if len(matches) != ifaceLen {
+		return nil, types.IncompleteMatchErr(patternInterface, matches)
+	}
+
+	iIf := patternInterface.SubexpIndex(names[1])
+	iState := patternInterface.SubexpIndex(names[2])
+
+	iface := strings.TrimSpace(matches[iIf])
+	state := strings.ToLower(strings.TrimSpace(matches[iState]))
+
+	l := &types.InterfaceLog{
+		Base:       types.Base{Type: types.Interface, Original: msg, Extra: extra},
+		Local:      src,
+		Timestamp:  ts,
+		Interface:  iface,
+		OperStatus: state,
+		State:      types.DOWN,
+	}
+	if strings.HasPrefix(state, "administratively") {
+		l.AdminStatus = "down"
+		l.OperStatus = "down"
+	}
+	if state == "up" {
+		l.State = types.UP
+	}
+	return l, nil
+}
+
+func ParseLACP(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	names := patternLACP.SubexpNames()
+	matches := patternLACP.FindStringSubmatch(msg)
+	if len(matches) != lacpLen {
+		return nil, types.IncompleteMatchErr(patternLACP, matches)
+	}
+
+	iReason := patternLACP.SubexpIndex(names[1])
+	iIf := patternLACP.SubexpIndex(names[2])
+	iParent := patternLACP.SubexpIndex(names[3])
+
+	reason := strings.TrimSpace(matches[iReason])
+	iface := strings.TrimSpace(matches[iIf])
+	parent := strings.TrimSpace(matches[iParent])
+
+	l := &types.InterfaceLog{
+		Base:       types.Base{Type: types.Interface, Original: msg, Extra: extra},
+		Local:      src,
+		Timestamp:  ts,
+		Interface:  iface,
+		Parent:     parent,
+		OperStatus: "down",
+		Reason:     reason,
+		State:      types.DOWN,
+	}
+	return l, nil
+}
+
+func ParseNotification(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	names := patternNotification.SubexpNames()
+	matches := patternNotification.FindStringSubmatch(msg)
+	if len(matches) != notifyLen {
+		return nil, types.IncompleteMatchErr(patternNotification, matches)
+	}
+
+	iDirection := patternNotification.SubexpIndex(names[1])
+	iRemote := patternNotification.SubexpIndex(names[2])
+	iTable := patternNotification.SubexpIndex(names[3])
+	iASN := patternNotification.SubexpIndex(names[4])
+	iCode := patternNotification.SubexpIndex(names[5])
+	iSubcode := patternNotification.SubexpIndex(names[6])
+	iText := patternNotification.SubexpIndex(names[7])
+
+	direction := strings.TrimSpace(matches[iDirection])
+	remote := strings.TrimSpace(matches[iRemote])
+	table := strings.TrimSpace(matches[iTable])
+	asn := strings.TrimSpace(matches[iASN])
+	code, _ := strconv.ParseUint(matches[iCode], 10, 0)
+	subcode, _ := strconv.ParseUint(matches[iSubcode], 10, 0)
+	text := strings.TrimSpace(matches[iText])
+
+	l := &types.BGPNotificationLog{
+		Base:      types.Base{Type: types.BGPNotification, Original: msg, Extra: extra},
+		Timestamp: ts,
+		Local:     src,
+		Remote:    remote,
 		RemoteAS:  asn,
 		Table:     table,
+		Received:  direction == received,
+		Code:      uint(code),
+		Subcode:   uint(subcode),
+		Text:      text,
 	}
-	if strings.Contains(strings.ToLower(state), "established") {
-		l.State = types.UP
+	return l, nil
+}
+
+func ParsePrefixLimit(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	names := patternPrefixLimit.SubexpNames()
+	matches := patternPrefixLimit.FindStringSubmatch(msg)
+	if len(matches) != prefixLen {
+		return nil, types.IncompleteMatchErr(patternPrefixLimit, matches)
+	}
+
+	iRemote := patternPrefixLimit.SubexpIndex(names[1])
+	iTable := patternPrefixLimit.SubexpIndex(names[2])
+	iASN := patternPrefixLimit.SubexpIndex(names[3])
+	iAFISAFI := patternPrefixLimit.SubexpIndex(names[4])
+	iLimit := patternPrefixLimit.SubexpIndex(names[5])
+	iKind := patternPrefixLimit.SubexpIndex(names[6])
+	iCount := patternPrefixLimit.SubexpIndex(names[7])
+	iTeardown := patternPrefixLimit.SubexpIndex(names[8])
+
+	remote := strings.TrimSpace(matches[iRemote])
+	table := strings.TrimSpace(matches[iTable])
+	asn := strings.TrimSpace(matches[iASN])
+	afiSafi := strings.TrimSpace(matches[iAFISAFI])
+	limit, _ := strconv.ParseUint(matches[iLimit], 10, 0)
+	count, _ := strconv.ParseUint(matches[iCount], 10, 0)
+
+	l := &types.BGPPrefixLimitLog{
+		Base:      types.Base{Type: types.BGPPrefixLimit, Original: msg, Extra: extra},
+		Timestamp: ts,
+		Local:     src,
+		Remote:    remote,
+		RemoteAS:  asn,
+		Table:     table,
+		AFISAFI:   afiSafi,
+		Limit:     uint(limit),
+		Count:     uint(count),
+		Threshold: matches[iKind] == prefixThreshold,
+		TornDown:  matches[iTeardown] != "",
 	}
 	return l, nil
 }
 
+// Platform is the name this package's parser is registered under.
+const Platform string = "arista_eos"
+
+func init() {
+	types.RegisterPatterns(Platform, Patterns)
+	types.RegisterAlias("eos", Platform)
+	types.RegisterHints(Platform, "Bgp", "Isis", "Ospf", "Ospf3", "Bfd", "Lacp", "Ebra", "Rib")
+}
+
 func Parse(req *types.Request) ([]types.Log, error) {
-	logs := make([]types.Log, 0, len(req.Messages))
-	for _, msg := range req.Messages {
-		for pattern, parser := range parseMap {
-			if pattern.MatchString(msg) {
-				l, err := parser(msg, req.Source, req.Timestamp, req.Extra)
-				if err != nil {
-					return nil, err
-				}
-				if l != nil {
-					logs = append(logs, l)
-				}
-			}
-		}
-	}
-	if len(logs) == 0 {
-		return nil, types.ErrNoMatchingParser
-	}
-	return logs, nil
+	return Patterns.Parse(req)
+}
+
+// ParseAll parses every message of a request it can, recording the outcome of each message.
+func ParseAll(req *types.Request) *types.Result {
+	return Patterns.ParseAll(req)
 }
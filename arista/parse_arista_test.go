@@ -66,6 +66,9 @@ func Test_ParseBGP(t *testing.T) {
 		assert.Equal(t, "10.0.0.1", attrs["remote"])
 		assert.Equal(t, "65000", attrs["remote_as"])
 		assert.Equal(t, "default", attrs["table"])
+		assert.Equal(t, types.ESTABLISHED, attrs["fsm_state"])
+		assert.Equal(t, types.OPENCONFIRM, attrs["previous_fsm_state"])
+		assert.Equal(t, "Established", attrs["event"])
 		assert.Equal(t, msg, attrs["original"])
 		assert.False(t, result.Down())
 		assert.True(t, result.Up())
@@ -80,6 +83,9 @@ func Test_ParseBGP(t *testing.T) {
 		assert.Equal(t, "10.4.255.121", attrs["remote"])
 		assert.Equal(t, "65004", attrs["remote_as"])
 		assert.Equal(t, "default", attrs["table"])
+		assert.Equal(t, types.IDLE, attrs["fsm_state"])
+		assert.Equal(t, types.ESTABLISHED, attrs["previous_fsm_state"])
+		assert.Equal(t, "AdminShutdown", attrs["event"])
 		assert.Equal(t, msg, attrs["original"])
 		assert.False(t, result.Up())
 		assert.True(t, result.Down())
@@ -91,6 +97,227 @@ func Test_ParseBGP(t *testing.T) {
 	})
 }
 
+func Test_ParseOSPF(t *testing.T) {
+	t.Run("up", func(t *testing.T) {
+		t.Parallel()
+		msg := "%OSPF-4-OSPF_ADJACENCY_ESTABLISHED: NGB 10.255.0.2, instance 1, VRF default, interface 10.0.0.1 (Ethernet1) adjacency established"
+		result, err := arista.ParseOSPF(msg, "leaf0401", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.UP, attrs["state"])
+		assert.Equal(t, "10.255.0.2", attrs["remote"])
+		assert.Equal(t, "Ethernet1", attrs["interface"])
+		assert.Equal(t, "FULL", attrs["new_state"])
+		assert.Empty(t, attrs["reason"])
+		assert.Equal(t, uint(2), attrs["version"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Down())
+		assert.True(t, result.Up())
+	})
+	t.Run("down", func(t *testing.T) {
+		t.Parallel()
+		msg := "%OSPF-4-OSPF_ADJACENCY_TEARDOWN: NGB 10.255.0.2, interface 10.0.0.1 adjacency dropped: nbr did not list our router ID, state was: FULL"
+		result, err := arista.ParseOSPF(msg, "leaf0401", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.DOWN, attrs["state"])
+		assert.Equal(t, "10.255.0.2", attrs["remote"])
+		assert.Equal(t, "10.0.0.1", attrs["interface"])
+		assert.Equal(t, "FULL", attrs["old_state"])
+		assert.Equal(t, "DOWN", attrs["new_state"])
+		assert.Equal(t, "nbr did not list our router ID", attrs["reason"])
+		assert.False(t, result.Up())
+		assert.True(t, result.Down())
+	})
+	t.Run("v3", func(t *testing.T) {
+		t.Parallel()
+		msg := "%OSPF3-4-OSPF3_ADJACENCY_ESTABLISHED: NGB 10.255.0.2, interface fe80::1 (Ethernet2) adjacency established"
+		result, err := arista.ParseOSPF(msg, "leaf0401", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, uint(3), attrs["version"])
+		assert.Equal(t, "Ethernet2", attrs["interface"])
+		assert.True(t, result.Up())
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := arista.ParseOSPF("%OSPF-4-OSPF_ADJACENCY_TEARDOWN: NGB 10.255.0.2", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_ParseBFD(t *testing.T) {
+	t.Run("up", func(t *testing.T) {
+		t.Parallel()
+		msg := "%BFD-5-STATE_CHANGE: peer (vrf:default, ip:10.0.0.2, intf:Ethernet1, srcIp:10.0.0.1, type:normal, tunnelId:0) changed state from Init to Up diag NoDiagnostic"
+		result, err := arista.ParseBFD(msg, "leaf0401", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.UP, attrs["state"])
+		assert.Equal(t, "10.0.0.2", attrs["remote"])
+		assert.Equal(t, "Ethernet1", attrs["interface"])
+		assert.Equal(t, "NoDiagnostic", attrs["diagnostic"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.True(t, result.Up())
+	})
+	t.Run("down", func(t *testing.T) {
+		t.Parallel()
+		msg := "%BFD-5-STATE_CHANGE: peer (vrf:default, ip:10.0.0.2, intf:Ethernet1, srcIp:10.0.0.1, type:normal) changed state from Up to Down diag ControlDetectTimeExpired"
+		result, err := arista.ParseBFD(msg, "leaf0401", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.DOWN, attrs["state"])
+		assert.Equal(t, "ControlDetectTimeExpired", attrs["diagnostic"])
+		assert.True(t, result.Down())
+	})
+	t.Run("init", func(t *testing.T) {
+		t.Parallel()
+		msg := "%BFD-5-STATE_CHANGE: peer (vrf:default, ip:10.0.0.2, intf:Ethernet1, srcIp:10.0.0.1, type:normal) changed state from Down to Init diag NoDiagnostic"
+		result, err := arista.ParseBFD(msg, "leaf0401", time.Now(), nil)
+		require.NoError(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := arista.ParseBFD("%BFD-5-STATE_CHANGE: peer (vrf:default, ip:10.0.0.2)", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_ParseInterface(t *testing.T) {
+	t.Run("lineproto down", func(t *testing.T) {
+		t.Parallel()
+		msg := "%LINEPROTO-5-UPDOWN: Line protocol on Interface Ethernet1 (to-spine01), changed state to down"
+		result, err := arista.ParseInterface(msg, "leaf0401", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.DOWN, attrs["state"])
+		assert.Equal(t, "Ethernet1", attrs["interface"])
+		assert.Equal(t, "down", attrs["oper_status"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.True(t, result.Down())
+	})
+	t.Run("link up", func(t *testing.T) {
+		t.Parallel()
+		msg := "%LINK-3-UPDOWN: Interface Ethernet49/1, changed state to up"
+		result, err := arista.ParseInterface(msg, "leaf0401", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, "Ethernet49/1", attrs["interface"])
+		assert.True(t, result.Up())
+	})
+	t.Run("eth up", func(t *testing.T) {
+		t.Parallel()
+		msg := "%ETH-4-INTF_UP: Interface Ethernet2 changed state to up"
+		result, err := arista.ParseInterface(msg, "leaf0401", time.Now(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Ethernet2", result.Attrs()["interface"])
+		assert.True(t, result.Up())
+	})
+	t.Run("admin down", func(t *testing.T) {
+		t.Parallel()
+		msg := "%LINK-3-UPDOWN: Interface Ethernet2, changed state to administratively down"
+		result, err := arista.ParseInterface(msg, "leaf0401", time.Now(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "down", result.Attrs()["admin_status"])
+		assert.True(t, result.Down())
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := arista.ParseInterface("%LINK-3-UPDOWN: Interface Ethernet2", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_ParseLACP(t *testing.T) {
+	t.Run("suspended", func(t *testing.T) {
+		t.Parallel()
+		msg := "%LACP-4-SUSPEND_INDIVIDUAL: Interface Ethernet3 is suspended as it is configured in Port-Channel10 and is not receiving LACP PDUs"
+		result, err := arista.ParseLACP(msg, "leaf0401", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.DOWN, attrs["state"])
+		assert.Equal(t, "Ethernet3", attrs["interface"])
+		assert.Equal(t, "Port-Channel10", attrs["parent"])
+		assert.Equal(t, "Interface Ethernet3 is suspended as it is configured in Port-Channel10 and is not receiving LACP PDUs", attrs["reason"])
+		assert.True(t, result.Down())
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := arista.ParseLACP("%LACP-4-SUSPEND_INDIVIDUAL: no interface", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_ParseNotification(t *testing.T) {
+	t.Run("received", func(t *testing.T) {
+		t.Parallel()
+		msg := "%BGP-3-NOTIFICATION: received from neighbor 10.0.0.1 (VRF default AS 65000) 4/0 (Hold Timer Expired) 0 bytes"
+		result, err := arista.ParseNotification(msg, "leaf0401", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, "10.0.0.1", attrs["remote"])
+		assert.Equal(t, "65000", attrs["remote_as"])
+		assert.Equal(t, "default", attrs["table"])
+		assert.Equal(t, true, attrs["received"])
+		assert.Equal(t, uint(4), attrs["code"])
+		assert.Equal(t, uint(0), attrs["subcode"])
+		assert.Equal(t, "Hold Timer Expired", attrs["text"])
+		assert.Equal(t, msg, attrs["original"])
+	})
+	t.Run("sent", func(t *testing.T) {
+		t.Parallel()
+		msg := "%BGP-3-NOTIFICATION: sent to neighbor 10.0.0.1 (VRF default AS 65000) 6/2 (Cease/administrative shutdown) 0 bytes"
+		result, err := arista.ParseNotification(msg, "leaf0401", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, false, attrs["received"])
+		assert.Equal(t, uint(6), attrs["code"])
+		assert.Equal(t, uint(2), attrs["subcode"])
+		assert.Equal(t, "Cease/administrative shutdown", attrs["text"])
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := arista.ParseNotification("%BGP-3-NOTIFICATION: received from neighbor 10.0.0.1", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_ParsePrefixLimit(t *testing.T) {
+	t.Run("torn down", func(t *testing.T) {
+		t.Parallel()
+		msg := "%BGP-3-MAXPFX: peer 10.0.0.1 (VRF default AS 65000) IPv4 Unicast prefix limit 100 exceeded, received 101 prefixes, session torn down"
+		result, err := arista.ParsePrefixLimit(msg, "leaf0401", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, "10.0.0.1", attrs["remote"])
+		assert.Equal(t, "default", attrs["table"])
+		assert.Equal(t, "65000", attrs["remote_as"])
+		assert.Equal(t, "IPv4 Unicast", attrs["afi_safi"])
+		assert.Equal(t, uint(100), attrs["limit"])
+		assert.Equal(t, uint(101), attrs["count"])
+		assert.Equal(t, false, attrs["threshold"])
+		assert.Equal(t, true, attrs["torn_down"])
+		assert.True(t, result.Down())
+	})
+	t.Run("warning only", func(t *testing.T) {
+		t.Parallel()
+		msg := "%BGP-3-MAXPFX: peer 10.0.0.1 (VRF default AS 65000) IPv6 Unicast prefix limit 100 reached, received 100 prefixes"
+		result, err := arista.ParsePrefixLimit(msg, "leaf0401", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, "IPv6 Unicast", attrs["afi_safi"])
+		assert.Equal(t, true, attrs["threshold"])
+		assert.Equal(t, false, attrs["torn_down"])
+		assert.False(t, result.Down())
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := arista.ParsePrefixLimit("%BGP-3-MAXPFX: peer 10.0.0.1", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
 func Test_Parse(t *testing.T) {
 	t.Run("isis", func(t *testing.T) {
 		t.Parallel()
@@ -106,6 +333,30 @@ func Test_Parse(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotNil(t, result)
 	})
+	t.Run("ospf", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"%OSPF-4-OSPF_ADJACENCY_ESTABLISHED: NGB 10.255.0.2, interface 10.0.0.1 (Ethernet1) adjacency established"}}
+		result, err := arista.Parse(req)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.True(t, result[0].Is(types.OSPFLogType))
+	})
+	t.Run("bfd", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"%BFD-5-STATE_CHANGE: peer (vrf:default, ip:10.0.0.2, intf:Ethernet1, srcIp:10.0.0.1, type:normal) changed state from Up to Down diag ControlDetectTimeExpired"}}
+		result, err := arista.Parse(req)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.True(t, result[0].Is(types.BFDLogType))
+	})
+	t.Run("interface", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"%LINEPROTO-5-UPDOWN: Line protocol on Interface Ethernet1 (to-spine01), changed state to down"}}
+		result, err := arista.Parse(req)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.True(t, result[0].Is(types.InterfaceLogType))
+	})
 	t.Run("no match", func(t *testing.T) {
 		t.Parallel()
 		req := &types.Request{Messages: []string{"this has no match"}}
@@ -137,3 +388,27 @@ func Test_Parse(t *testing.T) {
 		assert.Nil(t, result)
 	})
 }
+
+func Test_Explain(t *testing.T) {
+	fixtures := map[string]string{
+		"isis":             "L2 Neighbor State Change for SystemID 1004.2550.1100 on Et5 to DOWN: interface went down or no IP address on interface",
+		"bgp":              "peer 10.4.255.121 (VRF default AS 65004) old state Established event AdminShutdown new state Idle",
+		"ospf":             "%OSPF-4-OSPF_ADJACENCY_ESTABLISHED: NGB 10.255.0.2, instance 1, VRF default, interface 10.0.0.1 (Ethernet1) adjacency established",
+		"bfd":              "%BFD-5-STATE_CHANGE: peer (vrf:default, ip:10.0.0.2, intf:Ethernet1, srcIp:10.0.0.1, type:normal) changed state from Up to Down diag ControlDetectTimeExpired",
+		"interface":        "%LINEPROTO-5-UPDOWN: Line protocol on Interface Ethernet1 (to-spine01), changed state to down",
+		"bgp_notification": "%BGP-3-NOTIFICATION: received from neighbor 10.0.0.1 (VRF default AS 65000) 4/0 (Hold Timer Expired) 0 bytes",
+		"bgp_prefix_limit": "%BGP-3-MAXPFX: peer 10.0.0.1 (VRF default AS 65000) IPv4 Unicast prefix limit 100 exceeded, received 101 prefixes, session torn down",
+		"lacp":             "%LACP-4-SUSPEND_INDIVIDUAL: Interface Ethernet3 is suspended as it is configured in Port-Channel10 and is not receiving LACP PDUs",
+	}
+	for _, pattern := range arista.Patterns.List() {
+		msg, ok := fixtures[pattern.Name]
+		if !assert.True(t, ok, "no fixture for pattern %s", pattern.Name) {
+			continue
+		}
+		result := arista.Patterns.Explain(&types.Request{Messages: []string{msg}})
+		require.Len(t, result.Outcomes, 1)
+		assert.Equal(t, pattern.Name, result.Outcomes[0].Pattern)
+		assert.NoError(t, result.Outcomes[0].Err, pattern.Name)
+		assert.NotEmpty(t, result.Outcomes[0].Captures, pattern.Name)
+	}
+}
@@ -0,0 +1,97 @@
+package bird
+
+import (
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/stellaraf/go-parselog/types"
+)
+
+// BIRD does not log the neighbor address on state changes, so the protocol instance name (e.g.
+// bgp1) is used as the remote.
+var patternBGP = regexp.MustCompile(`(?:<\w+> )?(?P<remote>[\w\-\.]+): State changed to (?P<state>[a-z]+)$`)
+
+// BIRD's state change messages don't say which type of protocol changed state, so protocols
+// named after BIRD's other protocol types, as BIRD names them by default (e.g. kernel1, ospf1),
+// are not BGP sessions.
+var patternProtocol = regexp.MustCompile(`([\w\-\.]+): State changed to `)
+
+var patternOtherProtocol = regexp.MustCompile(`(?i)^(?:aggregator|babel|bfd|device|direct|kernel|l3vpn|mrt|ospf|perf|pipe|radv|rip|rpki|static)(?:[\d_\-].*)?$`)
+
+var bgpProtocols struct {
+	mu    sync.RWMutex
+	isBGP func(name string) bool
+}
+
+// SetBGPProtocols sets the function that decides whether a protocol instance is a BGP session,
+// e.g. by a naming convention or a list of sessions. State changes of other protocols are not
+// matched. A nil function restores the default, which accepts every protocol not named after
+// another BIRD protocol type.
+func SetBGPProtocols(isBGP func(name string) bool) {
+	bgpProtocols.mu.Lock()
+	defer bgpProtocols.mu.Unlock()
+	bgpProtocols.isBGP = isBGP
+}
+
+func isBGPProtocol(name string) bool {
+	bgpProtocols.mu.RLock()
+	defer bgpProtocols.mu.RUnlock()
+	if bgpProtocols.isBGP != nil {
+		return bgpProtocols.isBGP(name)
+	}
+	return !patternOtherProtocol.MatchString(name)
+}
+
+func matchBGP(msg string) bool {
+	matches := patternProtocol.FindStringSubmatch(msg)
+	return matches != nil && isBGPProtocol(matches[1])
+}
+
+// Platform is the name this package's parser is registered under.
+const Platform string = "bird"
+
+// Patterns are evaluated in order against each message, and may be extended or overridden.
+var Patterns = types.NewPatterns(
+	types.Pattern{Name: "bgp", Match: matchBGP, Parse: ParseBGP, Regexp: patternBGP, StopOnMatch: true},
+)
+
+func ParseBGP(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	groups, err := types.Groups(patternBGP, msg)
+	if err != nil {
+		return nil, err
+	}
+	remote := groups["remote"]
+	state := groups["state"]
+
+	if !isBGPProtocol(remote) {
+		return nil, types.ErrNoMatchingParser
+	}
+
+	// BIRD also logs intermediate protocol states (start, feed, stop, flush), which are skipped.
+	if state != "up" && state != "down" {
+		return nil, nil
+	}
+
+	l := &types.BGPLog{
+		Base:      types.Base{Type: types.BGP, Original: msg, Extra: extra},
+		Timestamp: ts,
+		Local:     src,
+		Remote:    remote,
+		State:     types.DOWN,
+		FSMState:  types.IDLE,
+	}
+	if state == "up" {
+		l.State = types.UP
+		l.FSMState = types.ESTABLISHED
+	}
+	return l, nil
+}
+
+func init() {
+	types.RegisterPatterns(Platform, Patterns)
+}
+
+func Parse(req *types.Request) ([]types.Log, error) {
+	return Patterns.Parse(req)
+}
@@ -0,0 +1,121 @@
+package bird_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stellaraf/go-parselog/bird"
+	"github.com/stellaraf/go-parselog/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseBGP(t *testing.T) {
+	t.Run("up", func(t *testing.T) {
+		t.Parallel()
+		msg := "<INFO> rs_client_a: State changed to up"
+		result, err := bird.ParseBGP(msg, "rs02.sea01", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.UP, attrs["state"])
+		assert.Equal(t, "rs_client_a", attrs["remote"])
+		assert.Equal(t, "rs02.sea01", attrs["local"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Down())
+		assert.True(t, result.Up())
+	})
+	t.Run("down", func(t *testing.T) {
+		t.Parallel()
+		msg := "bgp1: State changed to down"
+		result, err := bird.ParseBGP(msg, "rs02.sea01", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.DOWN, attrs["state"])
+		assert.Equal(t, "bgp1", attrs["remote"])
+		assert.Equal(t, types.IDLE, attrs["fsm_state"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Up())
+		assert.True(t, result.Down())
+	})
+	t.Run("intermediate", func(t *testing.T) {
+		t.Parallel()
+		result, err := bird.ParseBGP("bgp1: State changed to feed", "rs02.sea01", time.Now(), nil)
+		require.NoError(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := bird.ParseBGP("bgp1: State changed to ", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+	t.Run("other protocols", func(t *testing.T) {
+		t.Parallel()
+		for _, name := range []string{"kernel1", "device1", "ospf1", "static1", "bfd_core", "direct"} {
+			msg := name + ": State changed to up"
+			_, err := bird.ParseBGP(msg, "rs02.sea01", time.Now(), nil)
+			assert.ErrorIs(t, err, types.ErrNoMatchingParser, name)
+			result, err := bird.Parse(&types.Request{Messages: []string{msg}})
+			assert.ErrorIs(t, err, types.ErrNoMatchingParser, name)
+			assert.Nil(t, result, name)
+		}
+	})
+}
+
+func Test_SetBGPProtocols(t *testing.T) {
+	bird.SetBGPProtocols(func(name string) bool { return strings.HasPrefix(name, "bgp_") })
+	t.Cleanup(func() { bird.SetBGPProtocols(nil) })
+
+	result, err := bird.Parse(&types.Request{Messages: []string{"bgp_transit1: State changed to up"}})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "bgp_transit1", result[0].Attrs()["remote"])
+	_, err = bird.Parse(&types.Request{Messages: []string{"rs_client_a: State changed to up"}})
+	assert.ErrorIs(t, err, types.ErrNoMatchingParser)
+}
+
+func Test_Parse(t *testing.T) {
+	t.Run("bgp", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"bgp1: State changed to up"}}
+		result, err := bird.Parse(req)
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+	t.Run("no match", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"this has no match"}}
+		result, err := bird.Parse(req)
+		assert.ErrorIs(t, err, types.ErrNoMatchingParser)
+		assert.Nil(t, result)
+	})
+	t.Run("incomplete", func(t *testing.T) {
+		t.Parallel()
+		_, err := bird.Parse(&types.Request{Messages: []string{"bgp1: State changed to "}})
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+	t.Run("only intermediate", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"bgp1: State changed to start", "bgp1: State changed to feed"}}
+		result, err := bird.Parse(req)
+		assert.ErrorIs(t, err, types.ErrNoMatchingParser)
+		assert.Nil(t, result)
+	})
+}
+
+func Test_Explain(t *testing.T) {
+	fixtures := map[string]string{
+		"bgp": "<INFO> rs_client_a: State changed to up",
+	}
+	for _, pattern := range bird.Patterns.List() {
+		msg, ok := fixtures[pattern.Name]
+		if !assert.True(t, ok, "no fixture for pattern %s", pattern.Name) {
+			continue
+		}
+		result := bird.Patterns.Explain(&types.Request{Messages: []string{msg}})
+		require.Len(t, result.Outcomes, 1)
+		assert.Equal(t, pattern.Name, result.Outcomes[0].Pattern)
+		assert.NoError(t, result.Outcomes[0].Err, pattern.Name)
+		assert.NotEmpty(t, result.Outcomes[0].Captures, pattern.Name)
+	}
+}
@@ -0,0 +1,62 @@
+// Command parselog-http serves the parselog HTTP API.
+//
+//	parselog-http -listen :8080
+//	curl -d '{"message": "...", "platform": "junos", "source": "er01", "timestamp": "2024-07-13 21:57:59"}' localhost:8080/parse
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/stellaraf/go-parselog"
+	"github.com/stellaraf/go-parselog/httpapi"
+	"github.com/stellaraf/go-parselog/unmatched"
+)
+
+const shutdownTimeout = 10 * time.Second
+
+func main() {
+	var (
+		listen        = flag.String("listen", ":8080", "`address` to listen on")
+		maxBodySize   = flag.Int64("max-body-size", 0, "maximum request body size in `bytes` (default 10MiB)")
+		unmatchedFile = flag.String("unmatched", "", "`file` to append messages that match no pattern to, as JSON lines")
+	)
+	flag.Parse()
+
+	if *unmatchedFile != "" {
+		f, err := os.OpenFile(*unmatchedFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		parselog.SetUnmatchedSink(unmatched.NewJSONL(f))
+	}
+
+	server := &http.Server{
+		Addr:              *listen,
+		Handler:           &httpapi.Handler{MaxBodySize: *maxBodySize},
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	errs := make(chan error, 1)
+	go func() { errs <- server.ListenAndServe() }()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	select {
+	case err := <-errs:
+		log.Fatal(err)
+	case <-signals:
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Print(err)
+	}
+}
@@ -0,0 +1,372 @@
+// Command parselog parses logs from files or stdin and prints the normalized logs, for trying out
+// and debugging patterns without writing code.
+//
+// Each line of input may be a raw RFC 5424 or RFC 3164 syslog message, a Request as JSON, or a
+// bare message. The platform is taken from -platform, then from the request, and is otherwise
+// detected.
+//
+//	parselog -platform junos messages.log
+//	parselog -auto -output table < syslog.log
+//	parselog -explain -platform eos requests.jsonl
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/stellaraf/go-parselog"
+	"github.com/stellaraf/go-parselog/syslog"
+	"github.com/stellaraf/go-parselog/types"
+)
+
+const (
+	exitOK = iota
+	exitFailed
+	exitUsage
+)
+
+const maxLineSize int = 1024 * 1024
+
+// Attributes shown in their own column, or not at all, rather than in the details column.
+var columnAttrs = map[string]bool{
+	"type": true, "state": true, "local": true, "remote": true, "timestamp": true, "extra": true, "original": true,
+}
+
+type options struct {
+	platform string
+	auto     bool
+	output   string
+	explain  bool
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("parselog", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: parselog [flags] [file ...]")
+		flags.PrintDefaults()
+	}
+	var opts options
+	flags.StringVar(&opts.platform, "platform", "", "`platform` of every message, overriding the platform of JSON requests")
+	flags.BoolVar(&opts.auto, "auto", false, "detect the platform of every message, ignoring the platform of JSON requests")
+	flags.StringVar(&opts.output, "output", "json", "output `format`: json, table or csv")
+	flags.BoolVar(&opts.explain, "explain", false, "show the pattern that matched each message and the named groups it captured")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	if opts.platform != "" && opts.auto {
+		fmt.Fprintln(stderr, "parselog: -platform and -auto can't be used together")
+		return exitUsage
+	}
+	if opts.platform != "" {
+		if _, ok := types.Lookup(opts.platform); !ok {
+			fmt.Fprintf(stderr, "parselog: unknown platform '%s', expected one of %s\n", opts.platform, strings.Join(parselog.Platforms(), ", "))
+			return exitUsage
+		}
+	}
+	out, err := newWriter(opts.output, opts.explain, stdout)
+	if err != nil {
+		fmt.Fprintf(stderr, "parselog: %s\n", err)
+		return exitUsage
+	}
+
+	inputs := flags.Args()
+	if len(inputs) == 0 {
+		inputs = []string{"-"}
+	}
+	failed := false
+	for _, name := range inputs {
+		ok, err := process(name, stdin, opts, out, stderr)
+		if err != nil {
+			fmt.Fprintf(stderr, "parselog: %s\n", err)
+			return exitFailed
+		}
+		failed = failed || !ok
+	}
+	if err := out.flush(); err != nil {
+		fmt.Fprintf(stderr, "parselog: %s\n", err)
+		return exitFailed
+	}
+	if failed {
+		return exitFailed
+	}
+	return exitOK
+}
+
+// process parses every line of an input, reporting whether every message was parsed. Errors
+// parsing messages are written to stderr; only errors reading the input or writing output are
+// returned.
+func process(name string, stdin io.Reader, opts options, out writer, stderr io.Writer) (bool, error) {
+	r := stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return false, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	ok := true
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		in := fmt.Sprintf("%s:%d", name, n)
+		req, err := request(line)
+		if err != nil {
+			fmt.Fprintf(stderr, "%s: %s\n", in, err)
+			ok = false
+			continue
+		}
+		switch {
+		case opts.platform != "":
+			req.Platform = opts.platform
+		case opts.auto:
+			req.Platform = ""
+		}
+		parsed, err := parse(in, req, opts.explain, out, stderr)
+		if err != nil {
+			return false, err
+		}
+		ok = ok && parsed
+	}
+	return ok, scanner.Err()
+}
+
+// request builds a request from a line of input.
+func request(line string) (*types.Request, error) {
+	if strings.HasPrefix(line, "{") {
+		var req types.Request
+		if err := json.Unmarshal([]byte(line), &req); err != nil {
+			return nil, fmt.Errorf("invalid request: %w", err)
+		}
+		return &req, nil
+	}
+	if strings.HasPrefix(line, "<") {
+		if msg, err := syslog.Parse(line); err == nil {
+			return msg.Request(""), nil
+		}
+	}
+	return &types.Request{Messages: []string{line}, Timestamp: time.Now()}, nil
+}
+
+func parse(in string, req *types.Request, explain bool, out writer, stderr io.Writer) (bool, error) {
+	if _, ok := types.Lookup(req.Platform); !ok {
+		detection, err := parselog.DetectPlatform(req, parselog.DefaultDetectPolicy)
+		if err != nil {
+			fmt.Fprintf(stderr, "%s: %s%s\n", in, err, candidates(detection.Candidates))
+			return false, nil
+		}
+		detected := *req
+		detected.Platform = detection.Platform
+		req = &detected
+	}
+
+	var result *types.Result
+	var err error
+	if explain {
+		result, err = parselog.Explain(req)
+	} else {
+		result, err = parselog.ParseAll(req)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %s\n", in, err)
+		return false, nil
+	}
+	if explain {
+		return len(result.Failed()) == 0, out.outcomes(in, req.Platform, result.Outcomes)
+	}
+	for _, outcome := range result.Failed() {
+		fmt.Fprintf(stderr, "%s: %s\n", in, outcome.Err)
+	}
+	return len(result.Failed()) == 0, out.logs(in, req.Platform, result.Logs)
+}
+
+func candidates(candidates []parselog.Candidate) string {
+	if len(candidates) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		parts = append(parts, fmt.Sprintf("%s %.2f", c.Platform, c.Confidence))
+	}
+	return " (candidates: " + strings.Join(parts, ", ") + ")"
+}
+
+// writer writes logs, or outcomes in explain mode, in an output format.
+type writer interface {
+	logs(in, platform string, logs []types.Log) error
+	outcomes(in, platform string, outcomes []types.Outcome) error
+	flush() error
+}
+
+func newWriter(format string, explain bool, w io.Writer) (writer, error) {
+	switch format {
+	case "json":
+		return &jsonWriter{enc: json.NewEncoder(w)}, nil
+	case "table":
+		t := &rowWriter{tw: tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)}
+		t.row = t.tableRow
+		return t, t.header(explain)
+	case "csv":
+		c := &rowWriter{cw: csv.NewWriter(w)}
+		c.row = c.csvRow
+		return c, c.header(explain)
+	}
+	return nil, fmt.Errorf("unknown output format '%s', expected json, table or csv", format)
+}
+
+type jsonWriter struct {
+	enc *json.Encoder
+}
+
+func (j *jsonWriter) logs(in, platform string, logs []types.Log) error {
+	for _, l := range logs {
+		if err := j.enc.Encode(l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// explanation is the JSON form of an outcome in explain mode.
+type explanation struct {
+	Input    string            `json:"input"`
+	Platform string            `json:"platform"`
+	Index    int               `json:"index"`
+	Message  string            `json:"message"`
+	Pattern  string            `json:"pattern"`
+	Captures map[string]string `json:"captures"`
+	Error    string            `json:"error,omitempty"`
+}
+
+func (j *jsonWriter) outcomes(in, platform string, outcomes []types.Outcome) error {
+	for _, o := range outcomes {
+		e := explanation{Input: in, Platform: platform, Index: o.Index, Message: o.Message, Pattern: o.Pattern, Captures: o.Captures}
+		if o.Err != nil {
+			e.Error = o.Err.Error()
+		}
+		if err := j.enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (j *jsonWriter) flush() error {
+	return nil
+}
+
+// rowWriter writes logs or outcomes as rows of a table or CSV.
+type rowWriter struct {
+	tw  *tabwriter.Writer
+	cw  *csv.Writer
+	row func(fields ...string) error
+}
+
+func (r *rowWriter) header(explain bool) error {
+	if explain {
+		return r.row("INPUT", "PLATFORM", "MESSAGE", "PATTERN", "CAPTURES", "ERROR")
+	}
+	return r.row("INPUT", "PLATFORM", "TYPE", "STATE", "LOCAL", "REMOTE", "TIMESTAMP", "DETAILS")
+}
+
+func (r *rowWriter) tableRow(fields ...string) error {
+	_, err := fmt.Fprintln(r.tw, strings.Join(fields, "\t"))
+	return err
+}
+
+func (r *rowWriter) csvRow(fields ...string) error {
+	return r.cw.Write(fields)
+}
+
+func (r *rowWriter) logs(in, platform string, logs []types.Log) error {
+	for _, l := range logs {
+		attrs := l.Attrs()
+		var state string
+		switch {
+		case l.Up():
+			state = "up"
+		case l.Down():
+			state = "down"
+		}
+		var timestamp string
+		if ts, ok := attrs["timestamp"].(time.Time); ok && !ts.IsZero() {
+			timestamp = ts.Format(time.RFC3339)
+		}
+		details := make(map[string]string)
+		for key, value := range attrs {
+			if !columnAttrs[key] {
+				details[key] = fmt.Sprint(value)
+			}
+		}
+		logType, _ := attrs["type"].(types.LogType)
+		err := r.row(in, platform, logType.String(), state, fmt.Sprint(attrs["local"]), remote(attrs), timestamp, pairs(details))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *rowWriter) outcomes(in, platform string, outcomes []types.Outcome) error {
+	for _, o := range outcomes {
+		var errText string
+		if o.Err != nil {
+			errText = o.Err.Error()
+		}
+		if err := r.row(in, platform, o.Message, o.Pattern, pairs(o.Captures), errText); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *rowWriter) flush() error {
+	if r.tw != nil {
+		return r.tw.Flush()
+	}
+	r.cw.Flush()
+	return r.cw.Error()
+}
+
+func remote(attrs map[string]any) string {
+	if value, ok := attrs["remote"]; ok {
+		return fmt.Sprint(value)
+	}
+	return ""
+}
+
+// pairs formats non-empty values as key=value, sorted by key.
+func pairs(values map[string]string) string {
+	keys := make([]string, 0, len(values))
+	for key, value := range values {
+		if value != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+"="+values[key])
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	syslogLine  = `<28>1 2024-07-13T21:57:59Z er01 rpd - - - BGP peer 10.0.0.2 (External AS 65000) changed state from Established to Idle (event RecvNotify) (instance master)`
+	requestLine = `{"message": "L2 Neighbor State Change for SystemID 1004.2550.1100 on Et5 to UP", "platform": "eos", "source": "leaf0401", "timestamp": "2024-07-13 21:57:59"}`
+	bareLine    = `IS-IS new L2 adjacency to er02 on ae0.3613`
+)
+
+func runWith(t *testing.T, input string, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(input), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func Test_Run(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+		code, stdout, stderr := runWith(t, syslogLine+"\n"+requestLine+"\n")
+		assert.Equal(t, exitOK, code, stderr)
+		lines := strings.Split(strings.TrimSpace(stdout), "\n")
+		require.Len(t, lines, 2)
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &decoded))
+		assert.Equal(t, "10.0.0.2", decoded["remote"])
+		assert.Equal(t, "er01", decoded["local"])
+	})
+	t.Run("platform", func(t *testing.T) {
+		t.Parallel()
+		code, stdout, _ := runWith(t, bareLine+"\nsomething else\n", "-platform", "junos", "-output", "csv")
+		assert.Equal(t, exitFailed, code)
+		records, err := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, []string{"-:1", "junos", "isis", "up", "", "er02"}, records[1][:6])
+	})
+	t.Run("auto", func(t *testing.T) {
+		t.Parallel()
+		code, stdout, stderr := runWith(t, requestLine+"\n", "-auto", "-output", "table")
+		assert.Equal(t, exitOK, code, stderr)
+		assert.Contains(t, stdout, "arista_eos")
+		assert.Contains(t, stdout, "interface=Et5")
+	})
+	t.Run("explain", func(t *testing.T) {
+		t.Parallel()
+		code, stdout, stderr := runWith(t, syslogLine+"\n", "-explain")
+		assert.Equal(t, exitOK, code, stderr)
+		var decoded explanation
+		require.NoError(t, json.Unmarshal([]byte(stdout), &decoded))
+		assert.Equal(t, "junos", decoded.Platform)
+		assert.Equal(t, "bgp", decoded.Pattern)
+		assert.Equal(t, "10.0.0.2", decoded.Captures["remote"])
+		assert.Empty(t, decoded.Error)
+	})
+	t.Run("unmatched", func(t *testing.T) {
+		t.Parallel()
+		code, stdout, stderr := runWith(t, "not a log message\n")
+		assert.Equal(t, exitFailed, code)
+		assert.Empty(t, stdout)
+		assert.Contains(t, stderr, "-:1: ")
+	})
+	t.Run("usage", func(t *testing.T) {
+		t.Parallel()
+		code, _, _ := runWith(t, "", "-platform", "junos", "-auto")
+		assert.Equal(t, exitUsage, code)
+		code, _, _ = runWith(t, "", "-platform", "nope")
+		assert.Equal(t, exitUsage, code)
+		code, _, _ = runWith(t, "", "-output", "xml")
+		assert.Equal(t, exitUsage, code)
+	})
+}
@@ -0,0 +1,152 @@
+// Command parselogd listens for syslog over UDP, TCP and TLS, and writes each parsed log as a
+// line of JSON to stdout or files.
+//
+//	parselogd -udp :514 -tcp :514 -tls :6514 -cert server.crt -key server.key -output logs.jsonl
+package main
+
+import (
+	"crypto/tls"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/stellaraf/go-parselog"
+	"github.com/stellaraf/go-parselog/listener"
+	"github.com/stellaraf/go-parselog/types"
+	"github.com/stellaraf/go-parselog/unmatched"
+)
+
+// outputs collects the repeatable -output flag.
+type outputs []string
+
+func (o *outputs) String() string {
+	return strings.Join(*o, ",")
+}
+
+func (o *outputs) Set(value string) error {
+	*o = append(*o, value)
+	return nil
+}
+
+func main() {
+	var (
+		udpAddr       = flag.String("udp", "", "UDP `address` to listen on, e.g. :514")
+		tcpAddr       = flag.String("tcp", "", "TCP `address` to listen on, e.g. :514")
+		tlsAddr       = flag.String("tls", "", "TLS `address` to listen on, e.g. :6514")
+		certFile      = flag.String("cert", "", "TLS certificate `file`")
+		keyFile       = flag.String("key", "", "TLS private key `file`")
+		platform      = flag.String("platform", "", "`platform` of every message; detected per message when empty")
+		unmatchedFile = flag.String("unmatched", "", "`file` to append messages that match no pattern to, as JSON lines")
+		maxSize       = flag.Int("max-size", 0, "maximum message size in `bytes` (default 65536)")
+		outputPaths   outputs
+	)
+	flag.Var(&outputPaths, "output", "`file` to append logs to as JSON lines, or - for stdout; may be repeated (default -)")
+	flag.Parse()
+
+	errorLog := log.New(os.Stderr, "", log.LstdFlags)
+	if err := run(*udpAddr, *tcpAddr, *tlsAddr, *certFile, *keyFile, *platform, *unmatchedFile, *maxSize, outputPaths, errorLog); err != nil {
+		errorLog.Fatal(err)
+	}
+}
+
+func run(udpAddr, tcpAddr, tlsAddr, certFile, keyFile, platform, unmatchedFile string, maxSize int, outputPaths outputs, errorLog *log.Logger) error {
+	if udpAddr == "" && tcpAddr == "" && tlsAddr == "" {
+		return errors.New("at least one of -udp, -tcp or -tls is required")
+	}
+	if platform != "" {
+		if _, ok := types.Lookup(platform); !ok {
+			return fmt.Errorf("unknown platform '%s', expected one of %s", platform, strings.Join(parselog.Platforms(), ", "))
+		}
+	}
+	// The certificate is loaded before anything is served, so a bad certificate doesn't leave the
+	// other listeners running.
+	var tlsConfig *tls.Config
+	if tlsAddr != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return fmt.Errorf("loading TLS certificate: %w", err)
+		}
+		tlsConfig = &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	}
+	if len(outputPaths) == 0 {
+		outputPaths = outputs{"-"}
+	}
+
+	sinks := make([]listener.Sink, 0, len(outputPaths))
+	for _, path := range outputPaths {
+		w, err := open(path)
+		if err != nil {
+			return err
+		}
+		defer w.Close()
+		sinks = append(sinks, listener.NewJSON(w))
+	}
+	if unmatchedFile != "" {
+		w, err := open(unmatchedFile)
+		if err != nil {
+			return err
+		}
+		defer w.Close()
+		parselog.SetUnmatchedSink(unmatched.NewJSONL(w))
+	}
+
+	server := &listener.Server{
+		Platform:       platform,
+		Sink:           listener.Multi(sinks...),
+		ErrorLog:       errorLog,
+		MaxMessageSize: maxSize,
+	}
+	errs := make(chan error, 3)
+	serving := 0
+	if udpAddr != "" {
+		serving++
+		go func() { errs <- server.ListenAndServeUDP(udpAddr) }()
+	}
+	if tcpAddr != "" {
+		serving++
+		go func() { errs <- server.ListenAndServeTCP(tcpAddr) }()
+	}
+	if tlsAddr != "" {
+		serving++
+		go func() { errs <- server.ListenAndServeTLS(tlsAddr, tlsConfig) }()
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	var err error
+	select {
+	case <-signals:
+	case err = <-errs:
+		serving--
+	}
+	server.Close()
+	for ; serving > 0; serving-- {
+		<-errs
+	}
+	if errors.Is(err, listener.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// open opens a file for appending, or stdout for "-".
+func open(path string) (io.WriteCloser, error) {
+	if path == "-" {
+		return nopCloser{os.Stdout}, nil
+	}
+	return os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
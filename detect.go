@@ -0,0 +1,141 @@
+package parselog
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/stellaraf/go-parselog/types"
+)
+
+// AmbiguityPolicy decides what happens when more than one platform is equally likely.
+type AmbiguityPolicy uint
+
+const (
+	// AmbiguityReject fails detection with ErrAmbiguousPlatform.
+	AmbiguityReject AmbiguityPolicy = iota
+	// AmbiguityFirst picks the first candidate, by name.
+	AmbiguityFirst
+)
+
+const (
+	// Weight of a message that was recognized by a platform, but could not be fully parsed.
+	partialMatchWeight float64 = 0.5
+	// Share of the confidence given to an app-name hint, when the request has an app-name.
+	hintWeight float64 = 0.2
+)
+
+// DetectPolicy configures platform detection.
+type DetectPolicy struct {
+	// MinConfidence is the lowest confidence, from 0 to 1, at which a platform is accepted.
+	MinConfidence float64
+	// MinMargin is the lowest difference in confidence between the best and second best candidate
+	// for the best candidate to be considered unambiguous.
+	MinMargin float64
+	Ambiguity AmbiguityPolicy
+}
+
+// DefaultDetectPolicy requires at least half of the messages to be recognized and rejects ties.
+var DefaultDetectPolicy = DetectPolicy{MinConfidence: 0.5, MinMargin: 0.01, Ambiguity: AmbiguityReject}
+
+// Candidate is a platform considered during detection.
+type Candidate struct {
+	Platform   string  `json:"platform"`
+	Confidence float64 `json:"confidence"`
+}
+
+// Detection is the result of parsing a request with platform detection.
+type Detection struct {
+	Platform   string      `json:"platform"`
+	Confidence float64     `json:"confidence"`
+	Ambiguous  bool        `json:"ambiguous"`
+	Candidates []Candidate `json:"candidates"`
+	Logs       []Log       `json:"logs"`
+}
+
+// Detect parses a request whose platform is empty or not registered by trying every registered
+// platform. Each platform's confidence is the share of messages it parses, with messages it
+// recognizes but cannot parse counting for half. When the request's Extra includes an app-name
+// (types.ExtraAppName) a fifth of the confidence is given to platforms with a matching hint.
+//
+// Requests with a registered platform are parsed as-is, with a confidence of 1. When a platform is
+// detected but the request can't be parsed, the detection is returned along with the error.
+func Detect(request *Request, policy DetectPolicy) (*Detection, error) {
+	detection, err := DetectPlatform(request, policy)
+	if err != nil {
+		return detection, err
+	}
+	detected := *request
+	detected.Platform = detection.Platform
+	logs, err := Parse(&detected)
+	if err != nil {
+		return detection, err
+	}
+	detection.Logs = logs
+	return detection, nil
+}
+
+// DetectPlatform picks the platform of a request as Detect does, without parsing the request with
+// it, so the caller can parse it however it needs to, e.g. with ParseAll. The detection's Logs are
+// left empty.
+func DetectPlatform(request *Request, policy DetectPolicy) (*Detection, error) {
+	if _, ok := types.Lookup(request.Platform); ok {
+		candidates := []Candidate{{Platform: request.Platform, Confidence: 1}}
+		return &Detection{Platform: request.Platform, Confidence: 1, Candidates: candidates}, nil
+	}
+
+	candidates := score(request)
+	if len(candidates) == 0 || candidates[0].Confidence < policy.MinConfidence {
+		captureAll(request)
+		return &Detection{Candidates: candidates}, types.ErrNoMatchingPlatform
+	}
+	best := candidates[0]
+	ambiguous := len(candidates) > 1 && best.Confidence-candidates[1].Confidence < policy.MinMargin
+	if ambiguous && policy.Ambiguity == AmbiguityReject {
+		return &Detection{Ambiguous: true, Candidates: candidates}, types.ErrAmbiguousPlatform
+	}
+	return &Detection{
+		Platform:   best.Platform,
+		Confidence: best.Confidence,
+		Ambiguous:  ambiguous,
+		Candidates: candidates,
+	}, nil
+}
+
+// score returns the platforms with a non-zero confidence for the request, best first.
+func score(request *Request) []Candidate {
+	appName, _ := request.Extra[types.ExtraAppName].(string)
+	candidates := make([]Candidate, 0)
+	for _, platform := range types.Platforms() {
+		parser, ok := types.Lookup(platform)
+		if !ok || len(request.Messages) == 0 {
+			continue
+		}
+		var matched float64
+		for _, msg := range request.Messages {
+			single := *request
+			single.Platform = platform
+			single.Messages = []string{msg}
+			logs, err := parser(&single)
+			switch {
+			case err == nil && len(logs) != 0:
+				matched++
+			case err != nil && !errors.Is(err, types.ErrNoMatchingParser):
+				matched += partialMatchWeight
+			}
+		}
+		confidence := matched / float64(len(request.Messages))
+		if appName != "" {
+			confidence *= 1 - hintWeight
+			if types.HasHint(platform, appName) {
+				confidence += hintWeight
+			}
+		}
+		if confidence > 0 {
+			candidates = append(candidates, Candidate{Platform: platform, Confidence: confidence})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Confidence > candidates[j].Confidence
+	})
+	return candidates
+}
@@ -0,0 +1,145 @@
+package parselog_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellaraf/go-parselog"
+	"github.com/stellaraf/go-parselog/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ambiguousParser(req *types.Request) ([]types.Log, error) {
+	if req.Messages[0] != "DETECT_TEST_AMBIGUOUS" {
+		return nil, types.ErrNoMatchingParser
+	}
+	return []types.Log{&types.ISISLog{Base: types.Base{Type: types.ISIS}}}, nil
+}
+
+func Test_Detect(t *testing.T) {
+	parselog.Register("detect_test_a", ambiguousParser)
+	parselog.Register("detect_test_b", ambiguousParser)
+	t.Cleanup(func() {
+		parselog.Unregister("detect_test_a")
+		parselog.Unregister("detect_test_b")
+	})
+
+	t.Run("junos", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{
+			Messages:  []string{"BGP peer 2604:c0c0:3000::13e2 (Internal AS 14525) changed state from OpenConfirm to Established (event RecvKeepAlive) (instance master)"},
+			Timestamp: time.Now(),
+			Source:    "er01.gvl01.as14525.net",
+		}
+		result, err := parselog.Detect(req, parselog.DefaultDetectPolicy)
+		require.NoError(t, err)
+		assert.Equal(t, "junos", result.Platform)
+		assert.Equal(t, 1.0, result.Confidence)
+		assert.False(t, result.Ambiguous)
+		require.Len(t, result.Logs, 1)
+		assert.True(t, result.Logs[0].Is(parselog.BGPLogType))
+		assert.Empty(t, req.Platform)
+	})
+	t.Run("platform only", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{
+			"L2 Neighbor State Change for SystemID 1004.2550.1100 on Et5 to UP",
+			"%BGP-3-NOTIFICATION: received from neighbor 10.0.0.1",
+		}}
+		result, err := parselog.DetectPlatform(req, parselog.DefaultDetectPolicy)
+		require.NoError(t, err)
+		assert.Equal(t, "arista_eos", result.Platform)
+		assert.Equal(t, 0.75, result.Confidence)
+		assert.Empty(t, result.Logs)
+	})
+	t.Run("detected but unparsed", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{
+			"L2 Neighbor State Change for SystemID 1004.2550.1100 on Et5 to UP",
+			"%BGP-3-NOTIFICATION: received from neighbor 10.0.0.1",
+		}}
+		result, err := parselog.Detect(req, parselog.DefaultDetectPolicy)
+		assert.ErrorIs(t, err, parselog.ErrIncompleteMatch)
+		require.NotNil(t, result)
+		assert.Equal(t, "arista_eos", result.Platform)
+		assert.Equal(t, 0.75, result.Confidence)
+		assert.NotEmpty(t, result.Candidates)
+		assert.Empty(t, result.Logs)
+	})
+	t.Run("similar formats", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"%BGP-5-ADJCHANGE:  bgp-65000 [12345] (default) neighbor 10.0.0.1 Up"}}
+		result, err := parselog.Detect(req, parselog.DefaultDetectPolicy)
+		require.NoError(t, err)
+		assert.Equal(t, "nxos", result.Platform)
+		assert.Contains(t, result.Candidates, parselog.Candidate{Platform: "iosxe", Confidence: 0.5})
+	})
+	t.Run("app-name hint", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{
+			Messages: []string{"peer 10.0.0.1 (VRF default AS 65000) old state OpenConfirm event Established new state Established"},
+			Extra:    map[string]any{types.ExtraAppName: "Bgp"},
+		}
+		result, err := parselog.Detect(req, parselog.DefaultDetectPolicy)
+		require.NoError(t, err)
+		assert.Equal(t, "arista_eos", result.Platform)
+		assert.Equal(t, 1.0, result.Confidence)
+	})
+	t.Run("app-name mismatch", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{
+			Messages: []string{"peer 10.0.0.1 (VRF default AS 65000) old state OpenConfirm event Established new state Established"},
+			Extra:    map[string]any{types.ExtraAppName: "rpd"},
+		}
+		result, err := parselog.Detect(req, parselog.DefaultDetectPolicy)
+		require.NoError(t, err)
+		assert.Equal(t, "arista_eos", result.Platform)
+		assert.InDelta(t, 0.8, result.Confidence, 0.0001)
+	})
+	t.Run("known platform", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"L2 Neighbor State Change for SystemID 1004.2550.1100 on Et5 to UP"}, Platform: "eos"}
+		result, err := parselog.Detect(req, parselog.DefaultDetectPolicy)
+		require.NoError(t, err)
+		assert.Equal(t, "eos", result.Platform)
+		assert.Equal(t, 1.0, result.Confidence)
+	})
+	t.Run("ambiguous", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"DETECT_TEST_AMBIGUOUS"}}
+		result, err := parselog.Detect(req, parselog.DefaultDetectPolicy)
+		assert.ErrorIs(t, err, parselog.ErrAmbiguousPlatform)
+		assert.True(t, result.Ambiguous)
+		assert.Len(t, result.Candidates, 2)
+	})
+	t.Run("ambiguous first", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"DETECT_TEST_AMBIGUOUS"}}
+		policy := parselog.DefaultDetectPolicy
+		policy.Ambiguity = parselog.AmbiguityFirst
+		result, err := parselog.Detect(req, policy)
+		require.NoError(t, err)
+		assert.True(t, result.Ambiguous)
+		assert.Equal(t, "detect_test_a", result.Platform)
+		assert.Len(t, result.Logs, 1)
+	})
+	t.Run("below confidence", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{
+			"L2 Neighbor State Change for SystemID 1004.2550.1100 on Et5 to UP",
+			"something else entirely",
+			"and another",
+		}}
+		result, err := parselog.Detect(req, parselog.DefaultDetectPolicy)
+		assert.ErrorIs(t, err, parselog.ErrNoMatchingPlatform)
+		require.NotEmpty(t, result.Candidates)
+		assert.Equal(t, "arista_eos", result.Candidates[0].Platform)
+	})
+	t.Run("no match", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"something else entirely"}}
+		_, err := parselog.Detect(req, parselog.DefaultDetectPolicy)
+		assert.ErrorIs(t, err, parselog.ErrNoMatchingPlatform)
+	})
+}
@@ -0,0 +1,38 @@
+package frr
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/stellaraf/go-parselog/types"
+)
+
+var patternISIS = regexp.MustCompile(`Adjacency to (?P<remote>\S+) \((?P<iface>[^\)]+)\)(?: for level-[12])? changed from \S+ to (?P<state>[A-Za-z]+)(?:, (?P<reason>.+))?$`)
+var patternBGP = regexp.MustCompile(`%ADJCHANGE: neighbor (?P<remote>[^\s\(]+)(?:\([^\)]*\))?(?: in vrf (?P<table>\S+))? (?P<state>Up|Down)(?: (?P<reason>.+))?$`)
+
+const defaultTable string = "default"
+
+// Platform is the name this package's parser is registered under.
+const Platform string = "frr"
+
+// Patterns are evaluated in order against each message, and may be extended or overridden.
+var Patterns = types.NewPatterns(
+	types.Pattern{Name: "isis", Match: types.RegexpMatcher(regexp.MustCompile(`Adjacency to .+ changed from`)), Parse: ParseISIS, Regexp: patternISIS, StopOnMatch: true},
+	types.Pattern{Name: "bgp", Match: types.RegexpMatcher(regexp.MustCompile(`%ADJCHANGE: neighbor`)), Parse: ParseBGP, Regexp: patternBGP, StopOnMatch: true},
+)
+
+func ParseISIS(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	return types.ParseISISAdjacency(patternISIS, msg, src, ts, extra)
+}
+
+func ParseBGP(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	return types.ParseBGPAdjacency(patternBGP, defaultTable, msg, src, ts, extra)
+}
+
+func init() {
+	types.RegisterPatterns(Platform, Patterns)
+}
+
+func Parse(req *types.Request) ([]types.Log, error) {
+	return Patterns.Parse(req)
+}
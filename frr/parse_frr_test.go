@@ -0,0 +1,158 @@
+package frr_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellaraf/go-parselog/frr"
+	"github.com/stellaraf/go-parselog/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseISIS(t *testing.T) {
+	t.Run("up", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		msg := "isisd[812]: %ADJCHANGE: Adjacency to 0000.0000.0002 (eth1) for level-2 changed from Initializing to Up"
+		result, err := frr.ParseISIS(msg, "rs01.sea01", now, nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.UP, attrs["state"])
+		assert.Equal(t, "0000.0000.0002", attrs["remote"])
+		assert.Equal(t, "eth1", attrs["interface"])
+		assert.Empty(t, attrs["reason"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Down())
+		assert.True(t, result.Up())
+	})
+	t.Run("down", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		msg := "isisd[812]: %ADJCHANGE: Adjacency to 0000.0000.0002 (eth1) for level-2 changed from Up to Down, hold time expired"
+		result, err := frr.ParseISIS(msg, "rs01.sea01", now, nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.DOWN, attrs["state"])
+		assert.Equal(t, "0000.0000.0002", attrs["remote"])
+		assert.Equal(t, "eth1", attrs["interface"])
+		assert.Equal(t, "hold time expired", attrs["reason"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Up())
+		assert.True(t, result.Down())
+	})
+	t.Run("init", func(t *testing.T) {
+		t.Parallel()
+		msg := "isisd[812]: %ADJCHANGE: Adjacency to 0000.0000.0002 (eth1) for level-2 changed from Down to Initializing"
+		result, err := frr.ParseISIS(msg, "rs01.sea01", time.Now(), nil)
+		require.NoError(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := frr.ParseISIS("isisd[812]: %ADJCHANGE: Adjacency to 0000.0000.0002 changed from", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_ParseBGP(t *testing.T) {
+	t.Run("up", func(t *testing.T) {
+		t.Parallel()
+		msg := "bgpd[700]: [M59KS-A3ZXZ] %ADJCHANGE: neighbor 10.0.0.1(rs-client-a) in vrf default Up"
+		result, err := frr.ParseBGP(msg, "rs01.sea01", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.UP, attrs["state"])
+		assert.Equal(t, "10.0.0.1", attrs["remote"])
+		assert.Equal(t, "default", attrs["table"])
+		assert.Empty(t, attrs["reason"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Down())
+		assert.True(t, result.Up())
+	})
+	t.Run("down", func(t *testing.T) {
+		t.Parallel()
+		msg := "bgpd[700]: %ADJCHANGE: neighbor 2604:c0c0:3000::13e2(Unknown) in vrf blue Down Peer closed the session"
+		result, err := frr.ParseBGP(msg, "rs01.sea01", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.DOWN, attrs["state"])
+		assert.Equal(t, "2604:c0c0:3000::13e2", attrs["remote"])
+		assert.Equal(t, "blue", attrs["table"])
+		assert.Equal(t, "Peer closed the session", attrs["reason"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Up())
+		assert.True(t, result.Down())
+	})
+	t.Run("no vrf", func(t *testing.T) {
+		t.Parallel()
+		msg := "bgpd[700]: %ADJCHANGE: neighbor 10.0.0.1 Up"
+		result, err := frr.ParseBGP(msg, "rs01.sea01", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, "10.0.0.1", attrs["remote"])
+		assert.Equal(t, "default", attrs["table"])
+		assert.True(t, result.Up())
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := frr.ParseBGP("bgpd[700]: %ADJCHANGE: neighbor 10.0.0.1", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_Parse(t *testing.T) {
+	t.Run("isis", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"isisd[812]: %ADJCHANGE: Adjacency to 0000.0000.0002 (eth1) for level-2 changed from Initializing to Up"}}
+		result, err := frr.Parse(req)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.True(t, result[0].Is(types.ISISLogType))
+	})
+	t.Run("bgp", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"bgpd[700]: %ADJCHANGE: neighbor 10.0.0.1(rs-client-a) in vrf default Up"}}
+		result, err := frr.Parse(req)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.True(t, result[0].Is(types.BGPLogType))
+	})
+	t.Run("no match", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"this has no match"}}
+		result, err := frr.Parse(req)
+		assert.ErrorIs(t, err, types.ErrNoMatchingParser)
+		assert.Nil(t, result)
+	})
+	t.Run("with invalid", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{
+			Messages: []string{
+				"bgpd[700]: %ADJCHANGE: neighbor 10.0.0.1 Up",
+				"bgpd[700]: %ADJCHANGE: neighbor invalid",
+			},
+		}
+		result, err := frr.Parse(req)
+		require.ErrorIs(t, err, types.ErrIncompleteMatch)
+		assert.Nil(t, result)
+	})
+}
+
+func Test_Explain(t *testing.T) {
+	fixtures := map[string]string{
+		"isis": "isisd[812]: %ADJCHANGE: Adjacency to 0000.0000.0002 (eth1) for level-2 changed from Up to Down, hold time expired",
+		"bgp":  "bgpd[700]: %ADJCHANGE: neighbor 10.0.0.1(rs-client-a) in vrf default Up",
+	}
+	for _, pattern := range frr.Patterns.List() {
+		msg, ok := fixtures[pattern.Name]
+		if !assert.True(t, ok, "no fixture for pattern %s", pattern.Name) {
+			continue
+		}
+		result := frr.Patterns.Explain(&types.Request{Messages: []string{msg}})
+		require.Len(t, result.Outcomes, 1)
+		assert.Equal(t, pattern.Name, result.Outcomes[0].Pattern)
+		assert.NoError(t, result.Outcomes[0].Err, pattern.Name)
+		assert.NotEmpty(t, result.Outcomes[0].Captures, pattern.Name)
+	}
+}
@@ -0,0 +1,179 @@
+// Package httpapi serves parselog over HTTP, for tools that can't link Go.
+//
+//	POST /parse      parses a Request, or an array of Requests, as JSON
+//	GET  /platforms  lists the registered platforms
+package httpapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/stellaraf/go-parselog"
+	"github.com/stellaraf/go-parselog/types"
+)
+
+const defaultMaxBodySize int64 = 10 * 1024 * 1024
+
+// Response is the result of parsing a single request. Messages that couldn't be parsed have an
+// error in their outcome; Error is only set when the request as a whole couldn't be parsed, such
+// as when it is invalid or its platform couldn't be detected.
+type Response struct {
+	Platform string          `json:"platform"`
+	Logs     []types.Log     `json:"logs"`
+	Outcomes []types.Outcome `json:"outcomes"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// Platform describes a registered platform.
+type Platform struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+	// Patterns are the names of the platform's patterns in evaluation order, if it has patterns.
+	Patterns []string `json:"patterns"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Handler is an http.Handler serving the API. The zero value is ready to use.
+type Handler struct {
+	// DetectPolicy is used for requests whose platform is empty or not registered. The zero value
+	// uses parselog.DefaultDetectPolicy.
+	DetectPolicy parselog.DetectPolicy
+	// MaxBodySize limits the size of a request body in bytes. Defaults to 10MiB.
+	MaxBodySize int64
+
+	once sync.Once
+	mux  *http.ServeMux
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.once.Do(func() {
+		h.mux = http.NewServeMux()
+		h.mux.HandleFunc("POST /parse", h.parse)
+		h.mux.HandleFunc("GET /platforms", h.platforms)
+	})
+	h.mux.ServeHTTP(w, r)
+}
+
+// parse handles a single request, responding with a Response, or an array of requests,
+// responding with an array of Responses in the same order. An invalid request in an array doesn't
+// fail the others.
+func (h *Handler) parse(w http.ResponseWriter, r *http.Request) {
+	var body json.RawMessage
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, h.maxBodySize())).Decode(&body); err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			writeError(w, http.StatusRequestEntityTooLarge, err)
+			return
+		}
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) != 0 && trimmed[0] == '[' {
+		var batch []json.RawMessage
+		if err := json.Unmarshal(trimmed, &batch); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+			return
+		}
+		responses := make([]*Response, 0, len(batch))
+		for _, raw := range batch {
+			var req types.Request
+			if err := json.Unmarshal(raw, &req); err != nil {
+				responses = append(responses, failed("", err))
+				continue
+			}
+			responses = append(responses, h.handle(&req))
+		}
+		writeJSON(w, http.StatusOK, responses)
+		return
+	}
+
+	var req types.Request
+	if err := json.Unmarshal(trimmed, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	response := h.handle(&req)
+	status := http.StatusOK
+	if response.Error != "" {
+		status = http.StatusUnprocessableEntity
+	}
+	writeJSON(w, status, response)
+}
+
+// handle parses a request, detecting its platform first if it isn't registered.
+func (h *Handler) handle(req *types.Request) *Response {
+	if _, ok := types.Lookup(req.Platform); !ok {
+		detection, err := parselog.DetectPlatform(req, h.detectPolicy())
+		if err != nil {
+			return failed(req.Platform, err)
+		}
+		detected := *req
+		detected.Platform = detection.Platform
+		req = &detected
+	}
+	result, err := parselog.ParseAll(req)
+	if err != nil {
+		return failed(req.Platform, err)
+	}
+	return &Response{Platform: req.Platform, Logs: result.Logs, Outcomes: result.Outcomes}
+}
+
+func failed(platform string, err error) *Response {
+	return &Response{Platform: platform, Logs: make([]types.Log, 0), Outcomes: make([]types.Outcome, 0), Error: err.Error()}
+}
+
+func (h *Handler) platforms(w http.ResponseWriter, r *http.Request) {
+	aliases := make(map[string][]string)
+	for alias, target := range types.Aliases() {
+		aliases[target] = append(aliases[target], alias)
+	}
+	platforms := make([]Platform, 0)
+	for _, name := range parselog.Platforms() {
+		platform := Platform{Name: name, Aliases: aliases[name], Patterns: make([]string, 0)}
+		if platform.Aliases == nil {
+			platform.Aliases = make([]string, 0)
+		}
+		sort.Strings(platform.Aliases)
+		if patterns, ok := types.LookupPatterns(name); ok {
+			for _, pattern := range patterns.List() {
+				platform.Patterns = append(platform.Patterns, pattern.Name)
+			}
+		}
+		platforms = append(platforms, platform)
+	}
+	writeJSON(w, http.StatusOK, platforms)
+}
+
+func (h *Handler) maxBodySize() int64 {
+	if h.MaxBodySize <= 0 {
+		return defaultMaxBodySize
+	}
+	return h.MaxBodySize
+}
+
+func (h *Handler) detectPolicy() parselog.DetectPolicy {
+	if h.DetectPolicy == (parselog.DetectPolicy{}) {
+		return parselog.DefaultDetectPolicy
+	}
+	return h.DetectPolicy
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
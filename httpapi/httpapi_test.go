@@ -0,0 +1,123 @@
+package httpapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stellaraf/go-parselog"
+	"github.com/stellaraf/go-parselog/httpapi"
+	"github.com/stellaraf/go-parselog/unmatched"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	junosRequest  = `{"message": "BGP peer 10.0.0.2 (External AS 65000) changed state from Established to Idle (event RecvNotify) (instance master)__something else", "platform": "junos", "source": "er01", "timestamp": "2024-07-13 21:57:59"}`
+	aristaRequest = `{"message": "L2 Neighbor State Change for SystemID 1004.2550.1100 on Et5 to UP", "platform": "", "source": "leaf0401", "timestamp": "2024-07-13 21:57:59"}`
+)
+
+// response mirrors httpapi.Response with its logs and outcomes decoded generically.
+type response struct {
+	Platform string           `json:"platform"`
+	Logs     []map[string]any `json:"logs"`
+	Outcomes []map[string]any `json:"outcomes"`
+	Error    string           `json:"error"`
+}
+
+func do(t *testing.T, handler http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return rec
+}
+
+func Test_Parse(t *testing.T) {
+	handler := &httpapi.Handler{MaxBodySize: 4096}
+	t.Run("single", func(t *testing.T) {
+		t.Parallel()
+		rec := do(t, handler, http.MethodPost, "/parse", junosRequest)
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		var res response
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, "junos", res.Platform)
+		require.Len(t, res.Logs, 1)
+		assert.Equal(t, "10.0.0.2", res.Logs[0]["remote"])
+		assert.Equal(t, "er01", res.Logs[0]["local"])
+		require.Len(t, res.Outcomes, 2)
+		assert.Equal(t, "bgp", res.Outcomes[0]["pattern"])
+		assert.Empty(t, res.Outcomes[0]["error"])
+		assert.Contains(t, res.Outcomes[1]["error"], "did not match")
+	})
+	t.Run("batch", func(t *testing.T) {
+		t.Parallel()
+		body := "[" + junosRequest + "," + aristaRequest + `, {"platform": "junos"}]`
+		rec := do(t, handler, http.MethodPost, "/parse", body)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var res []response
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		require.Len(t, res, 3)
+		assert.Len(t, res[0].Logs, 1)
+		assert.Equal(t, "arista_eos", res[1].Platform)
+		require.Len(t, res[1].Logs, 1)
+		assert.Equal(t, "Et5", res[1].Logs[0]["interface"])
+		assert.Contains(t, res[2].Error, "source")
+		assert.Empty(t, res[2].Logs)
+	})
+	t.Run("undetected", func(t *testing.T) {
+		t.Parallel()
+		body := `{"message": "nothing to see here", "platform": "", "source": "x", "timestamp": "2024-07-13 21:57:59"}`
+		rec := do(t, handler, http.MethodPost, "/parse", body)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		var res response
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.NotEmpty(t, res.Error)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, http.StatusBadRequest, do(t, handler, http.MethodPost, "/parse", "{").Code)
+		assert.Equal(t, http.StatusBadRequest, do(t, handler, http.MethodPost, "/parse", `{"platform": "junos"}`).Code)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, do(t, handler, http.MethodPost, "/parse", `"`+strings.Repeat("x", 8192)+`"`).Code)
+		assert.Equal(t, http.StatusMethodNotAllowed, do(t, handler, http.MethodGet, "/parse", "").Code)
+	})
+}
+
+func Test_Platforms(t *testing.T) {
+	rec := do(t, &httpapi.Handler{}, http.MethodGet, "/platforms", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var platforms []httpapi.Platform
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &platforms))
+	names := make([]string, 0, len(platforms))
+	for _, platform := range platforms {
+		names = append(names, platform.Name)
+		if platform.Name == "arista_eos" {
+			assert.Contains(t, platform.Aliases, "eos")
+			assert.Contains(t, platform.Patterns, "bgp")
+		}
+	}
+	assert.Contains(t, names, "junos")
+	assert.Contains(t, names, "arista_eos")
+}
+
+func Test_ParseDetected(t *testing.T) {
+	sink := unmatched.NewMemory(0)
+	parselog.SetUnmatchedSink(sink)
+	t.Cleanup(func() { parselog.SetUnmatchedSink(nil) })
+
+	body := `{"message": "L2 Neighbor State Change for SystemID 1004.2550.1100 on Et5 to UP__%BGP-3-NOTIFICATION: received from neighbor 10.0.0.1__nothing to see here", "platform": "", "source": "leaf0401", "timestamp": "2024-07-13 21:57:59"}`
+	rec := do(t, &httpapi.Handler{}, http.MethodPost, "/parse", body)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var res response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, "arista_eos", res.Platform)
+	assert.Empty(t, res.Error)
+	assert.Len(t, res.Logs, 1)
+	require.Len(t, res.Outcomes, 3)
+	assert.Equal(t, "bgp_notification", res.Outcomes[1]["pattern"])
+	assert.NotEmpty(t, res.Outcomes[1]["error"])
+	require.Len(t, sink.Messages(), 1)
+	assert.Equal(t, "nothing to see here", sink.Messages()[0].Message)
+}
@@ -0,0 +1,156 @@
+package huawei
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stellaraf/go-parselog/types"
+)
+
+// VRP headers may be followed by a log type flag and a sequence number before the colon, as in
+// %%01BGP/2/hwBgpPeerBackwardTransition_active(l)[3]:
+var patternISIS = regexp.MustCompile(`ISIS/\d/ISIS_NBR_CHGE(?:_\w+)?(?:\([a-z]\))?(?:\[\d+\])?:.*\((?P<attrs>[^\(\)]+)\)\s*$`)
+var patternBGP = regexp.MustCompile(`BGP/\d/(?P<event>hwBgpPeer(?:BackwardTransition|Established))(?:_\w+)?(?:\([a-z]\))?(?:\[\d+\])?:.*\((?P<attrs>[^\(\)]+)\)\s*$`)
+var patternAttrKey = regexp.MustCompile(`(?:^|,)\s*(\w+)=`)
+
+const (
+	bgpEstablished string = "hwBgpPeerEstablished"
+	publicInstance string = "_public_"
+	defaultTable   string = "default"
+)
+
+// Platform is the name this package's parser is registered under.
+const Platform string = "huawei_vrp"
+
+// Patterns are evaluated in order against each message, and may be extended or overridden.
+var Patterns = types.NewPatterns(
+	types.Pattern{Name: "isis", Match: types.RegexpMatcher(regexp.MustCompile(`ISIS/\d/ISIS_NBR_CHGE`)), Parse: ParseISIS, Regexp: patternISIS, Capture: captureAttrs(patternISIS), StopOnMatch: true},
+	types.Pattern{Name: "bgp", Match: types.RegexpMatcher(regexp.MustCompile(`BGP/\d/hwBgpPeer(BackwardTransition|Established)`)), Parse: ParseBGP, Regexp: patternBGP, Capture: captureAttrs(patternBGP), StopOnMatch: true},
+)
+
+// parseAttrs parses the trailing "(Key=Value, Key=Value)" list VRP attaches to its alarm logs.
+// Values such as reasons may contain commas, so each value runs until the next ", Key=".
+func parseAttrs(s string) map[string]string {
+	attrs := make(map[string]string)
+	keys := patternAttrKey.FindAllStringSubmatchIndex(s, -1)
+	for i, key := range keys {
+		end := len(s)
+		if i+1 < len(keys) {
+			end = keys[i+1][0]
+		}
+		attrs[s[key[2]:key[3]]] = strings.TrimSpace(s[key[1]:end])
+	}
+	return attrs
+}
+
+// captureAttrs explains a message with the named groups of pattern and the attributes parsed from
+// its attrs group, which are what the parsers read.
+func captureAttrs(pattern *regexp.Regexp) types.Capturer {
+	return func(msg string, extra map[string]any) map[string]string {
+		captures := types.Captures(pattern, msg)
+		if captures == nil {
+			return nil
+		}
+		for key, value := range parseAttrs(captures["attrs"]) {
+			captures[key] = value
+		}
+		return captures
+	}
+}
+
+// firstAttr returns the first non-empty value of the given keys, as attribute names vary across VRP
+// releases.
+func firstAttr(attrs map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if value, ok := attrs[key]; ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func ParseISIS(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	groups, err := types.Groups(patternISIS, msg)
+	if err != nil {
+		return nil, err
+	}
+	attrs := parseAttrs(groups["attrs"])
+
+	remote := firstAttr(attrs, "IsisNbrSysId", "NeighborSysId", "NbrSysId")
+	iface := firstAttr(attrs, "IfName", "InterfaceName")
+	state := firstAttr(attrs, "IsisNbrState", "NeighborState", "NbrState")
+	reason := firstAttr(attrs, "Reason", "ChangeReason")
+
+	missing := make([]string, 0)
+	if remote == "" {
+		missing = append(missing, "IsisNbrSysId")
+	}
+	if iface == "" {
+		missing = append(missing, "IfName")
+	}
+	if state == "" {
+		missing = append(missing, "IsisNbrState")
+	}
+	if len(missing) != 0 {
+		return nil, types.MissingGroupsErr(missing...)
+	}
+
+	fields := map[string]string{"remote": remote, "iface": iface, "state": state, "reason": reason}
+	return types.ISISAdjacency(fields, msg, src, ts, extra), nil
+}
+
+func ParseBGP(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	groups, err := types.Groups(patternBGP, msg)
+	if err != nil {
+		return nil, err
+	}
+	event := groups["event"]
+	attrs := parseAttrs(groups["attrs"])
+
+	remote := firstAttr(attrs, "PeerRemoteAddr", "BgpPeerAddr", "PeerAddr")
+	asn := firstAttr(attrs, "PeerRemoteAs", "RemoteAs")
+	table := firstAttr(attrs, "VpnInstance", "VpnInstanceName")
+	reason := firstAttr(attrs, "BgpPeerUnavaiReason", "Reason")
+	fsmState := firstAttr(attrs, "BgpPeerState", "PeerState")
+
+	if remote == "" {
+		return nil, types.MissingGroupsErr("PeerRemoteAddr")
+	}
+
+	if table == "" || table == publicInstance {
+		table = defaultTable
+	}
+
+	l := &types.BGPLog{
+		Base:      types.Base{Type: types.BGP, Original: msg, Extra: extra},
+		Timestamp: ts,
+		Local:     src,
+		Remote:    remote,
+		State:     types.DOWN,
+		RemoteAS:  asn,
+		Table:     table,
+		Reason:    reason,
+	}
+	// VRP reports the FSM state using the numeric bgpPeerState values from RFC 4273.
+	if n, err := strconv.ParseUint(fsmState, 10, 0); err == nil && n >= uint64(types.IDLE) && n <= uint64(types.ESTABLISHED) {
+		l.FSMState = types.BGPState(n)
+	} else {
+		l.FSMState = types.ParseBGPState(fsmState)
+	}
+	if event == bgpEstablished {
+		l.State = types.UP
+		l.FSMState = types.ESTABLISHED
+	}
+	return l, nil
+}
+
+func init() {
+	types.RegisterPatterns(Platform, Patterns)
+	types.RegisterAlias("huawei", Platform)
+}
+
+func Parse(req *types.Request) ([]types.Log, error) {
+	return Patterns.Parse(req)
+}
@@ -0,0 +1,185 @@
+package huawei_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellaraf/go-parselog/huawei"
+	"github.com/stellaraf/go-parselog/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseISIS(t *testing.T) {
+	t.Run("up", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		msg := "%%01ISIS/2/ISIS_NBR_CHGE(l)[4]:The IS-IS neighbor state changed. (IsisSysInstance=1, IsisSysLevelIndex=2, IsisNbrSysId=0000.0000.0002, IsisNbrState=up, IfName=GigabitEthernet0/0/1, Reason=New adjacency)"
+		result, err := huawei.ParseISIS(msg, "ne01.hkg01", now, nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.UP, attrs["state"])
+		assert.Equal(t, "0000.0000.0002", attrs["remote"])
+		assert.Equal(t, "GigabitEthernet0/0/1", attrs["interface"])
+		assert.Equal(t, "New adjacency", attrs["reason"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Down())
+		assert.True(t, result.Up())
+	})
+	t.Run("down", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		msg := "%%01ISIS/2/ISIS_NBR_CHGE(l)[4]:The IS-IS neighbor state changed. (IsisSysInstance=1, IsisSysLevelIndex=2, IsisNbrSysId=0000.0000.0002, IsisNbrState=down, IfName=GigabitEthernet0/0/1, Reason=The hold timer expired)"
+		result, err := huawei.ParseISIS(msg, "ne01.hkg01", now, nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.DOWN, attrs["state"])
+		assert.Equal(t, "0000.0000.0002", attrs["remote"])
+		assert.Equal(t, "GigabitEthernet0/0/1", attrs["interface"])
+		assert.Equal(t, "The hold timer expired", attrs["reason"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Up())
+		assert.True(t, result.Down())
+	})
+	t.Run("comma in reason", func(t *testing.T) {
+		t.Parallel()
+		msg := "%%01ISIS/2/ISIS_NBR_CHGE(l)[4]:The IS-IS neighbor state changed. (IsisNbrSysId=0000.0000.0002, IsisNbrState=down, Reason=Interface down, BFD session down, IfName=GigabitEthernet0/0/1)"
+		result, err := huawei.ParseISIS(msg, "ne01.hkg01", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, "Interface down, BFD session down", attrs["reason"])
+		assert.Equal(t, "GigabitEthernet0/0/1", attrs["interface"])
+		assert.True(t, result.Down())
+	})
+	t.Run("init", func(t *testing.T) {
+		t.Parallel()
+		msg := "%%01ISIS/2/ISIS_NBR_CHGE(l)[4]:The IS-IS neighbor state changed. (IsisNbrSysId=0000.0000.0002, IsisNbrState=init, IfName=GigabitEthernet0/0/1)"
+		result, err := huawei.ParseISIS(msg, "ne01.hkg01", time.Now(), nil)
+		require.NoError(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := huawei.ParseISIS("%%01ISIS/2/ISIS_NBR_CHGE(l)[4]:The IS-IS neighbor state changed. (IsisNbrSysId=0000.0000.0002)", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+		_, err = huawei.ParseISIS("%%01ISIS/2/ISIS_NBR_CHGE(l)[4]:The IS-IS neighbor state changed.", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_ParseBGP(t *testing.T) {
+	t.Run("up", func(t *testing.T) {
+		t.Parallel()
+		msg := "%%01BGP/2/hwBgpPeerEstablished(l)[2]:OID 1.3.6.1.4.1.2011.5.25.177.1.3.1 The BGP FSM enters the Established state. (InstanceId=0, Afi=1, Safi=1, PeerType=1, PeerRemoteAddr=10.1.1.2, PeerRemoteAs=65000, InterfaceIndex=0, BgpPeerState=6, VpnInstance=_public_)"
+		result, err := huawei.ParseBGP(msg, "ne01.hkg01", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.UP, attrs["state"])
+		assert.Equal(t, "10.1.1.2", attrs["remote"])
+		assert.Equal(t, "65000", attrs["remote_as"])
+		assert.Equal(t, types.ESTABLISHED, attrs["fsm_state"])
+		assert.Equal(t, "default", attrs["table"])
+		assert.Empty(t, attrs["reason"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Down())
+		assert.True(t, result.Up())
+	})
+	t.Run("down", func(t *testing.T) {
+		t.Parallel()
+		msg := "%%01BGP/2/hwBgpPeerBackwardTransition_active(l)[3]:OID 1.3.6.1.4.1.2011.5.25.177.1.3.2 The BGP FSM moves from a higher numbered state to a lower numbered state. (BgpPeerAddr=10.1.1.2, InstanceId=0, Afi=1, Safi=1, PeerType=1, PeerRemoteAddr=10.1.1.2, InterfaceIndex=0, BgpPeerState=1, BgpPeerLastError=0x0404, BgpPeerUnavaiReason=Hold timer expired, InterfaceName=, VpnInstance=CUST-A)"
+		result, err := huawei.ParseBGP(msg, "ne01.hkg01", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.DOWN, attrs["state"])
+		assert.Equal(t, "10.1.1.2", attrs["remote"])
+		assert.Empty(t, attrs["remote_as"])
+		assert.Equal(t, "CUST-A", attrs["table"])
+		assert.Equal(t, "Hold timer expired", attrs["reason"])
+		assert.Equal(t, types.IDLE, attrs["fsm_state"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Up())
+		assert.True(t, result.Down())
+	})
+	t.Run("without oid", func(t *testing.T) {
+		t.Parallel()
+		msg := "%%01BGP/2/hwBgpPeerBackwardTransition_active(l)[3]:The BGP FSM moves from a higher numbered state to a lower numbered state. (PeerRemoteAddr=10.1.1.1, BgpPeerState=1, VpnInstance=_public_)"
+		result, err := huawei.ParseBGP(msg, "ne01.hkg01", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, "10.1.1.1", attrs["remote"])
+		assert.Equal(t, "default", attrs["table"])
+		assert.True(t, result.Down())
+	})
+	t.Run("comma in reason", func(t *testing.T) {
+		t.Parallel()
+		msg := "%%01BGP/2/hwBgpPeerBackwardTransition_active(l)[3]:OID 1.3.6.1.4.1.2011.5.25.177.1.3.2 The BGP FSM moves from a higher numbered state to a lower numbered state. (BgpPeerAddr=10.1.1.2, PeerRemoteAddr=10.1.1.2, BgpPeerState=1, BgpPeerUnavaiReason=Receive Notification, Cease, administrative shutdown, InterfaceName=, VpnInstance=CUST-A)"
+		result, err := huawei.ParseBGP(msg, "ne01.hkg01", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, "Receive Notification, Cease, administrative shutdown", attrs["reason"])
+		assert.Equal(t, "CUST-A", attrs["table"])
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := huawei.ParseBGP("%%01BGP/2/hwBgpPeerEstablished(l)[2]:OID 1.3.6.1.4.1.2011.5.25.177.1.3.1 The BGP FSM enters the Established state. (InstanceId=0)", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_Parse(t *testing.T) {
+	t.Run("isis", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"%%01ISIS/2/ISIS_NBR_CHGE(l)[4]:The IS-IS neighbor state changed. (IsisNbrSysId=0000.0000.0002, IsisNbrState=up, IfName=GigabitEthernet0/0/1)"}}
+		result, err := huawei.Parse(req)
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+	t.Run("bgp", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"%%01BGP/2/hwBgpPeerEstablished(l)[2]:OID 1.3.6.1.4.1.2011.5.25.177.1.3.1 The BGP FSM enters the Established state. (PeerRemoteAddr=10.1.1.2, VpnInstance=_public_)"}}
+		result, err := huawei.Parse(req)
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+	t.Run("no match", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"this has no match"}}
+		result, err := huawei.Parse(req)
+		assert.ErrorIs(t, err, types.ErrNoMatchingParser)
+		assert.Nil(t, result)
+	})
+	t.Run("with invalid", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{
+			Messages: []string{
+				"%%01BGP/2/hwBgpPeerEstablished(l)[2]:OID 1.3.6.1.4.1.2011.5.25.177.1.3.1 The BGP FSM enters the Established state. (PeerRemoteAddr=10.1.1.2, VpnInstance=_public_)",
+				"%%01BGP/2/hwBgpPeerBackwardTransition(l)[5]:invalid",
+			},
+		}
+		result, err := huawei.Parse(req)
+		require.ErrorIs(t, err, types.ErrIncompleteMatch)
+		assert.Nil(t, result)
+	})
+}
+
+func Test_Explain(t *testing.T) {
+	fixtures := map[string]string{
+		"isis": "%%01ISIS/2/ISIS_NBR_CHGE(l)[4]:The IS-IS neighbor state changed. (IsisSysInstance=1, IsisSysLevelIndex=2, IsisNbrSysId=0000.0000.0002, IsisNbrState=down, IfName=GigabitEthernet0/0/1, Reason=The hold timer expired)",
+		"bgp":  "%%01BGP/2/hwBgpPeerEstablished(l)[2]:OID 1.3.6.1.4.1.2011.5.25.177.1.3.1 The BGP FSM enters the Established state. (InstanceId=0, Afi=1, Safi=1, PeerType=1, PeerRemoteAddr=10.1.1.2, PeerRemoteAs=65000, InterfaceIndex=0, BgpPeerState=6, VpnInstance=_public_)",
+	}
+	for _, pattern := range huawei.Patterns.List() {
+		msg, ok := fixtures[pattern.Name]
+		if !assert.True(t, ok, "no fixture for pattern %s", pattern.Name) {
+			continue
+		}
+		result := huawei.Patterns.Explain(&types.Request{Messages: []string{msg}})
+		require.Len(t, result.Outcomes, 1)
+		assert.Equal(t, pattern.Name, result.Outcomes[0].Pattern)
+		assert.NoError(t, result.Outcomes[0].Err, pattern.Name)
+		assert.NotEmpty(t, result.Outcomes[0].Captures, pattern.Name)
+	}
+	result := huawei.Patterns.Explain(&types.Request{Messages: []string{fixtures["isis"]}})
+	require.Len(t, result.Outcomes, 1)
+	assert.Equal(t, "0000.0000.0002", result.Outcomes[0].Captures["IsisNbrSysId"])
+	assert.Equal(t, "The hold timer expired", result.Outcomes[0].Captures["Reason"])
+}
@@ -0,0 +1,38 @@
+package iosxe
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/stellaraf/go-parselog/types"
+)
+
+var patternISIS = regexp.MustCompile(`%(?:CLNS|ISIS)-5-ADJCHANGE:\s*ISIS: Adjacency to (?P<remote>\S+) \((?P<iface>[^\)]+)\)(?: \(L[12]\))? (?P<state>[A-Za-z]+)(?:, (?P<reason>.+))?$`)
+var patternBGP = regexp.MustCompile(`%BGP-5-ADJCHANGE:\s*neighbor (?P<remote>\S+)(?: vpn vrf (?P<table>\S+))? (?P<state>Up|Down)(?: (?P<reason>.+?))?\s*$`)
+
+const defaultTable string = "default"
+
+// Platform is the name this package's parser is registered under.
+const Platform string = "iosxe"
+
+// Patterns are evaluated in order against each message, and may be extended or overridden.
+var Patterns = types.NewPatterns(
+	types.Pattern{Name: "isis", Match: types.RegexpMatcher(regexp.MustCompile(`%(CLNS|ISIS)-5-ADJCHANGE`)), Parse: ParseISIS, Regexp: patternISIS, StopOnMatch: true},
+	types.Pattern{Name: "bgp", Match: types.RegexpMatcher(regexp.MustCompile(`%BGP-5-ADJCHANGE`)), Parse: ParseBGP, Regexp: patternBGP, StopOnMatch: true},
+)
+
+func ParseISIS(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	return types.ParseISISAdjacency(patternISIS, msg, src, ts, extra)
+}
+
+func ParseBGP(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	return types.ParseBGPAdjacency(patternBGP, defaultTable, msg, src, ts, extra)
+}
+
+func init() {
+	types.RegisterPatterns(Platform, Patterns)
+}
+
+func Parse(req *types.Request) ([]types.Log, error) {
+	return Patterns.Parse(req)
+}
@@ -0,0 +1,156 @@
+package iosxe_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellaraf/go-parselog/iosxe"
+	"github.com/stellaraf/go-parselog/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseISIS(t *testing.T) {
+	t.Run("up", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		msg := "%CLNS-5-ADJCHANGE: ISIS: Adjacency to er02.hnl01 (GigabitEthernet0/0/1) Up, new adjacency"
+		result, err := iosxe.ParseISIS(msg, "ce01.pdx01", now, nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.UP, attrs["state"])
+		assert.Equal(t, "er02.hnl01", attrs["remote"])
+		assert.Equal(t, "GigabitEthernet0/0/1", attrs["interface"])
+		assert.Equal(t, "new adjacency", attrs["reason"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Down())
+		assert.True(t, result.Up())
+	})
+	t.Run("down", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		msg := "%ISIS-5-ADJCHANGE: ISIS: Adjacency to er02.hnl01 (TenGigabitEthernet0/1/0) (L2) Down, hold time expired"
+		result, err := iosxe.ParseISIS(msg, "ce01.pdx01", now, nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.DOWN, attrs["state"])
+		assert.Equal(t, "er02.hnl01", attrs["remote"])
+		assert.Equal(t, "TenGigabitEthernet0/1/0", attrs["interface"])
+		assert.Equal(t, "hold time expired", attrs["reason"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Up())
+		assert.True(t, result.Down())
+	})
+	t.Run("init", func(t *testing.T) {
+		t.Parallel()
+		msg := "%CLNS-5-ADJCHANGE: ISIS: Adjacency to er02.hnl01 (GigabitEthernet0/0/1) Init, new adjacency"
+		result, err := iosxe.ParseISIS(msg, "ce01.pdx01", time.Now(), nil)
+		require.NoError(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := iosxe.ParseISIS("%CLNS-5-ADJCHANGE: ISIS: Adjacency to er02.hnl01", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_ParseBGP(t *testing.T) {
+	t.Run("up", func(t *testing.T) {
+		t.Parallel()
+		msg := "%BGP-5-ADJCHANGE: neighbor 10.0.0.1 Up"
+		result, err := iosxe.ParseBGP(msg, "ce01.pdx01", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.UP, attrs["state"])
+		assert.Equal(t, "10.0.0.1", attrs["remote"])
+		assert.Equal(t, "default", attrs["table"])
+		assert.Empty(t, attrs["remote_as"])
+		assert.Empty(t, attrs["reason"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Down())
+		assert.True(t, result.Up())
+	})
+	t.Run("trailing space", func(t *testing.T) {
+		t.Parallel()
+		result, err := iosxe.ParseBGP("%BGP-5-ADJCHANGE: neighbor 10.0.0.1 Up ", "ce01.pdx01", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, "10.0.0.1", attrs["remote"])
+		assert.Empty(t, attrs["reason"])
+		assert.True(t, result.Up())
+	})
+	t.Run("down", func(t *testing.T) {
+		t.Parallel()
+		msg := "%BGP-5-ADJCHANGE: neighbor 10.0.0.1 vpn vrf CUST-A Down BGP Notification sent"
+		result, err := iosxe.ParseBGP(msg, "ce01.pdx01", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.DOWN, attrs["state"])
+		assert.Equal(t, "10.0.0.1", attrs["remote"])
+		assert.Equal(t, "CUST-A", attrs["table"])
+		assert.Equal(t, "BGP Notification sent", attrs["reason"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Up())
+		assert.True(t, result.Down())
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := iosxe.ParseBGP("%BGP-5-ADJCHANGE: neighbor 10.0.0.1", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_Parse(t *testing.T) {
+	t.Run("isis", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"%CLNS-5-ADJCHANGE: ISIS: Adjacency to er02.hnl01 (GigabitEthernet0/0/1) Up, new adjacency"}}
+		result, err := iosxe.Parse(req)
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+	t.Run("bgp", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"%BGP-5-ADJCHANGE: neighbor 10.0.0.1 Up"}}
+		result, err := iosxe.Parse(req)
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+	t.Run("no match", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"this has no match"}}
+		result, err := iosxe.Parse(req)
+		assert.ErrorIs(t, err, types.ErrNoMatchingParser)
+		assert.Nil(t, result)
+	})
+	t.Run("with invalid", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{
+			Messages: []string{
+				"%BGP-5-ADJCHANGE: neighbor 10.0.0.1 Up",
+				"%BGP-5-ADJCHANGE: neighbor",
+			},
+		}
+		result, err := iosxe.Parse(req)
+		require.ErrorIs(t, err, types.ErrIncompleteMatch)
+		assert.Nil(t, result)
+	})
+}
+
+func Test_Explain(t *testing.T) {
+	fixtures := map[string]string{
+		"isis": "%ISIS-5-ADJCHANGE: ISIS: Adjacency to er02.hnl01 (TenGigabitEthernet0/1/0) (L2) Down, hold time expired",
+		"bgp":  "%BGP-5-ADJCHANGE: neighbor 10.0.0.1 vpn vrf CUST-A Down BGP Notification sent",
+	}
+	for _, pattern := range iosxe.Patterns.List() {
+		msg, ok := fixtures[pattern.Name]
+		if !assert.True(t, ok, "no fixture for pattern %s", pattern.Name) {
+			continue
+		}
+		result := iosxe.Patterns.Explain(&types.Request{Messages: []string{msg}})
+		require.Len(t, result.Outcomes, 1)
+		assert.Equal(t, pattern.Name, result.Outcomes[0].Pattern)
+		assert.NoError(t, result.Outcomes[0].Err, pattern.Name)
+		assert.NotEmpty(t, result.Outcomes[0].Captures, pattern.Name)
+	}
+}
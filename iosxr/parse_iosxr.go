@@ -0,0 +1,43 @@
+package iosxr
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/stellaraf/go-parselog/types"
+)
+
+var patternISIS = regexp.MustCompile(`%ROUTING-ISIS-5-ADJCHANGE\s*:\s*Adjacency to (?P<remote>\S+) \((?P<iface>[^\)]+)\) \(L[12]\) (?P<state>[A-Za-z]+)(?:, (?P<reason>.+))?$`)
+var patternBGP = regexp.MustCompile(`%ROUTING-BGP-5-ADJCHANGE(?:_DETAIL)?\s*:\s*neighbor (?P<remote>\S+) (?P<state>Up|Down)(?: - (?P<reason>.+?))? \(VRF: (?P<table>[^\)]+)\)(?: \(AS: (?P<remote_as>\d+)\))?.*$`)
+
+type Parser = types.MessageParser
+
+// Patterns are evaluated in order against each message, and may be extended or overridden.
+var Patterns = types.NewPatterns(
+	types.Pattern{Name: "isis", Match: types.RegexpMatcher(regexp.MustCompile(`%ROUTING-ISIS-5-ADJCHANGE`)), Parse: ParseISIS, Regexp: patternISIS, StopOnMatch: true},
+	types.Pattern{Name: "bgp", Match: types.RegexpMatcher(regexp.MustCompile(`%ROUTING-BGP-5-ADJCHANGE`)), Parse: ParseBGP, Regexp: patternBGP, StopOnMatch: true},
+)
+
+func ParseISIS(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	return types.ParseISISAdjacency(patternISIS, msg, src, ts, extra)
+}
+
+func ParseBGP(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	return types.ParseBGPAdjacency(patternBGP, "", msg, src, ts, extra)
+}
+
+// Platform is the name this package's parser is registered under.
+const Platform string = "iosxr"
+
+func init() {
+	types.RegisterPatterns(Platform, Patterns)
+}
+
+func Parse(req *types.Request) ([]types.Log, error) {
+	return Patterns.Parse(req)
+}
+
+// ParseAll parses every message of a request it can, recording the outcome of each message.
+func ParseAll(req *types.Request) *types.Result {
+	return Patterns.ParseAll(req)
+}
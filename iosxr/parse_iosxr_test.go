@@ -0,0 +1,172 @@
+package iosxr_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellaraf/go-parselog/iosxr"
+	"github.com/stellaraf/go-parselog/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseISIS(t *testing.T) {
+	t.Run("up", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		msg := "RP/0/RSP0/CPU0:Jul 13 21:57:59.123 UTC: isis[1010]: %ROUTING-ISIS-5-ADJCHANGE : Adjacency to er02.hnl01 (TenGigE0/0/0/1) (L2) Up, New adjacency"
+		result, err := iosxr.ParseISIS(msg, "cr01.sea01.as14525.net", now, nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.UP, attrs["state"])
+		assert.Equal(t, "er02.hnl01", attrs["remote"])
+		assert.Equal(t, "TenGigE0/0/0/1", attrs["interface"])
+		assert.Equal(t, "New adjacency", attrs["reason"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Down())
+		assert.True(t, result.Up())
+	})
+	t.Run("down", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		msg := "%ROUTING-ISIS-5-ADJCHANGE : Adjacency to er02.hnl01 (Bundle-Ether1.3613) (L2) Down, Neighbor forgot us"
+		result, err := iosxr.ParseISIS(msg, "cr01.sea01.as14525.net", now, nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.DOWN, attrs["state"])
+		assert.Equal(t, "er02.hnl01", attrs["remote"])
+		assert.Equal(t, "Bundle-Ether1.3613", attrs["interface"])
+		assert.Equal(t, "Neighbor forgot us", attrs["reason"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Up())
+		assert.True(t, result.Down())
+	})
+	t.Run("init", func(t *testing.T) {
+		t.Parallel()
+		msg := "%ROUTING-ISIS-5-ADJCHANGE : Adjacency to er02.hnl01 (Bundle-Ether1.3613) (L2) Init"
+		result, err := iosxr.ParseISIS(msg, "cr01.sea01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := iosxr.ParseISIS("%ROUTING-ISIS-5-ADJCHANGE : Adjacency to er02.hnl01", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_ParseBGP(t *testing.T) {
+	t.Run("up", func(t *testing.T) {
+		t.Parallel()
+		msg := "RP/0/RSP0/CPU0:Jul 13 21:57:59.123 UTC: bgp[1084]: %ROUTING-BGP-5-ADJCHANGE : neighbor 10.0.0.1 Up (VRF: default) (AS: 65000)"
+		result, err := iosxr.ParseBGP(msg, "cr01.sea01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.UP, attrs["state"])
+		assert.Equal(t, "10.0.0.1", attrs["remote"])
+		assert.Equal(t, "65000", attrs["remote_as"])
+		assert.Equal(t, "default", attrs["table"])
+		assert.Empty(t, attrs["reason"])
+		assert.Equal(t, types.ESTABLISHED, attrs["fsm_state"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Down())
+		assert.True(t, result.Up())
+	})
+	t.Run("down", func(t *testing.T) {
+		t.Parallel()
+		msg := "%ROUTING-BGP-5-ADJCHANGE : neighbor 2604:c0c0:3000::13e2 Down - BGP Notification sent, hold time expired (VRF: customer-a) (AS: 14525)"
+		result, err := iosxr.ParseBGP(msg, "cr01.sea01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.DOWN, attrs["state"])
+		assert.Equal(t, "2604:c0c0:3000::13e2", attrs["remote"])
+		assert.Equal(t, "14525", attrs["remote_as"])
+		assert.Equal(t, "customer-a", attrs["table"])
+		assert.Equal(t, "BGP Notification sent, hold time expired", attrs["reason"])
+		assert.Equal(t, types.IDLE, attrs["fsm_state"])
+		assert.Equal(t, types.ESTABLISHED, attrs["previous_fsm_state"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Up())
+		assert.True(t, result.Down())
+	})
+	t.Run("detail", func(t *testing.T) {
+		t.Parallel()
+		msg := "%ROUTING-BGP-5-ADJCHANGE_DETAIL : neighbor 10.0.0.1 Down - Peer closing down the session (VRF: default) (AS: 65000) (AFI/SAFI: 1/1)"
+		result, err := iosxr.ParseBGP(msg, "cr01.sea01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.DOWN, attrs["state"])
+		assert.Equal(t, "65000", attrs["remote_as"])
+		assert.Equal(t, "Peer closing down the session", attrs["reason"])
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := iosxr.ParseBGP("%ROUTING-BGP-5-ADJCHANGE : neighbor 10.0.0.1 Up", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_Parse(t *testing.T) {
+	t.Run("isis", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"%ROUTING-ISIS-5-ADJCHANGE : Adjacency to er02.hnl01 (TenGigE0/0/0/1) (L2) Up, New adjacency"}}
+		result, err := iosxr.Parse(req)
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+	t.Run("bgp", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"%ROUTING-BGP-5-ADJCHANGE : neighbor 10.0.0.1 Up (VRF: default) (AS: 65000)"}}
+		result, err := iosxr.Parse(req)
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+	t.Run("no match", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"this has no match"}}
+		result, err := iosxr.Parse(req)
+		assert.ErrorIs(t, err, types.ErrNoMatchingParser)
+		assert.Nil(t, result)
+	})
+	t.Run("with extra", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"%ROUTING-BGP-5-ADJCHANGE : neighbor 10.0.0.1 Up (VRF: default) (AS: 65000)"}, Extra: map[string]any{"key": "value"}}
+		result, err := iosxr.Parse(req)
+		require.NoError(t, err)
+		for _, _log := range result {
+			log, ok := _log.(*types.BGPLog)
+			require.True(t, ok)
+			assert.Equal(t, map[string]any{"key": "value"}, log.Extra)
+		}
+	})
+	t.Run("with invalid", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{
+			Messages: []string{
+				"%ROUTING-BGP-5-ADJCHANGE : neighbor 10.0.0.1 Up (VRF: default) (AS: 65000)",
+				"%ROUTING-BGP-5-ADJCHANGE : neighbor 10.0.0.1 invalid",
+			},
+		}
+		result, err := iosxr.Parse(req)
+		require.ErrorIs(t, err, types.ErrIncompleteMatch)
+		assert.Nil(t, result)
+	})
+}
+
+func Test_Explain(t *testing.T) {
+	fixtures := map[string]string{
+		"isis": "%ROUTING-ISIS-5-ADJCHANGE : Adjacency to er02.hnl01 (Bundle-Ether1.3613) (L2) Down, Neighbor forgot us",
+		"bgp":  "%ROUTING-BGP-5-ADJCHANGE : neighbor 10.0.0.1 Up (VRF: default) (AS: 65000)",
+	}
+	for _, pattern := range iosxr.Patterns.List() {
+		msg, ok := fixtures[pattern.Name]
+		if !assert.True(t, ok, "no fixture for pattern %s", pattern.Name) {
+			continue
+		}
+		result := iosxr.Patterns.Explain(&types.Request{Messages: []string{msg}})
+		require.Len(t, result.Outcomes, 1)
+		assert.Equal(t, pattern.Name, result.Outcomes[0].Pattern)
+		assert.NoError(t, result.Outcomes[0].Err, pattern.Name)
+		assert.NotEmpty(t, result.Outcomes[0].Captures, pattern.Name)
+	}
+}
@@ -2,34 +2,93 @@ package junos
 
 import (
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/stellaraf/go-parselog/syslog"
 	"github.com/stellaraf/go-parselog/types"
 )
 
-var patternISIS = regexp.MustCompile(`^IS-IS (?P<state>.+) .+ to (?P<remote>.+) on (?P<iface>[\S\.]+)(, reason: (?P<reason>.+))?$`)
-var patternBGP = regexp.MustCompile(`^BGP peer (?P<remote>.+) \(.+AS (?P<asn>\d+).+changed state from \S+ to (?P<state>\S+).*\(instance (?P<instance>\S+)\).*$`)
+var patternISIS = regexp.MustCompile(`^(?:RPD_ISIS_\w+: )?IS-IS (?P<state>.+) .+ to (?P<remote>.+) on (?P<iface>[\S\.]+)(, reason: (?P<reason>.+))?$`)
+var patternBGP = regexp.MustCompile(`^(?:BGP_\w+: )?BGP peer (?P<remote>.+) \(.+AS (?P<asn>\d+).+changed state from (?P<old_state>\S+) to (?P<state>\S+)(?: \(event (?P<event>\S+)\))?.*\(instance (?P<instance>\S+)\).*$`)
+var patternBFD = regexp.MustCompile(`^(?:BFDD_STATE_\w+: )?BFD Session (?P<remote>\S+) \((?:IFL )?(?P<iface>[^\)]+)\) state (?P<old_state>\S+) -> (?P<state>\S+) LD/RD\((?P<ld>\d+)/(?P<rd>\d+)\)(?:.* Local diag: (?P<diag>\S+))?.*$`)
+var patternBFDTrap = regexp.MustCompile(`^bfdd_trap_(?:shop|mhop)_state_\w+: local discriminator: (?P<ld>\d+), new state: (?P<state>\w+), interface: (?P<iface>[^,]+), peer addr: (?P<remote>\S+)$`)
+var patternLink = regexp.MustCompile(`^SNMP_TRAP_LINK_\w+: ifIndex (?P<if_index>\d+), ifAdminStatus (?P<admin>\w+)\(\d+\), ifOperStatus (?P<oper>\w+)\(\d+\), ifName (?P<iface>\S+)$`)
+var patternLACP = regexp.MustCompile(`^(?:LACPD_TIMEOUT: (?P<iface>[^:\s]+): (?P<reason>.+)|LACP_INTF_MUX_STATE_CHANGED: (?P<parent>[^:\s]+): (?P<member>[^:\s]+): (?P<change>Lacp state changed from \w+ to (?P<mux_state>\w+)).*)$`)
+var patternLDP = regexp.MustCompile(`^(?:RPD_LDP_SESSION\w+: )?LDP session (?P<session>\S+)(?: \(instance \S+\))? is (?P<state>up|down)(?:, reason: (?P<reason>.+))?$`)
+var patternLSP = regexp.MustCompile(`^(?:RPD_MPLS_LSP_\w+: )?MPLS LSP (?P<lsp>\S+) (?P<state>up|down|change) on (?:primary|secondary)\((?P<path>[^\)]*)\)(?:\s+Route\s+(?P<route>[^,]+?))?(?:,? [Rr]eason: (?P<reason>.+))?\s*$`)
+var patternRSVP = regexp.MustCompile(`^(?:RPD_RSVP_LSP\w+: )?RSVP LSP (?P<lsp>\S+) from (?P<ingress>\S+) to (?P<egress>\S+) is (?P<state>up|down)(?:, reason: (?P<reason>.+))?$`)
+var patternNotification = regexp.MustCompile(`^(?:\S+ )?NOTIFICATION (?P<direction>sent to|received from) (?P<remote>\S+) \((?:\S+ )?AS (?P<remote_as>\d+)\)(?: \(instance (?P<instance>\S+)\))?: code (?P<code>\d+) \((?P<code_text>[^\)]+)\)(?: subcode (?P<subcode>\d+) \((?P<subcode_text>[^\)]+)\))?.*$`)
+var patternPrefixLimit = regexp.MustCompile(`^BGP_PREFIX_(?P<kind>THRESH|LIMIT)_EXCEEDED: (?P<remote>\S+) \((?:\S+ )?AS (?P<remote_as>\d+)\): Configured maximum prefix-limit(?: threshold)?\((?P<limit>\d+)\) exceeded for (?P<afi_safi>\S+) nlri: (?P<count>\d+)(?: \(instance (?P<instance>\S+)\))?(?P<teardown>.*(?:torn down|tearing down|teardown))?.*$`)
+var patternStructured = regexp.MustCompile(`^(?:\S+: )?(?P<event>[A-Z][A-Z0-9_]+):? (?P<sd>\[junos@.*)$`)
+var patternStructuredPeer = regexp.MustCompile(`^(?P<remote>[^\s+]+)(?:\+\d+)?(?: \((?:\S+ )?AS (?P<remote_as>\d+)\))?$`)
+var patternOSPF = regexp.MustCompile(`^(?:RPD_OSPF_NBR(?:UP|DOWN): )?OSPF neighbor (?P<remote>\S+) \(realm (?P<realm>\S+) (?P<iface>\S+) area (?P<area>\S+)\) state changed from (?P<old_state>\S+) to (?P<new_state>\S+)(?: due to (?P<event>\S+))?(?: \(event reason: (?P<reason>.+)\))?$`)
 
 const (
-	bgpMinLen  int = 5
+	bgpMinLen  int = 7
 	isisMinLen int = 5
 	isisMaxLen int = 6
+	ospfLen    int = 9
+	bfdLen     int = 8
+	bfdTrapLen int = 5
+	linkLen    int = 5
+	lacpLen    int = 7
+	ldpLen     int = 4
+	lspLen     int = 6
+	rsvpLen    int = 6
+	notifyLen  int = 9
+	prefixLen  int = 9
 )
 
-type Parser func(string, string, time.Time, map[string]any) (types.Log, error)
+const (
+	defaultInstance string = "master"
+	received        string = "received from"
+)
 
-var parseMap = map[string]Parser{
-	"IS-IS":    ParseISIS,
-	"BGP peer": ParseBGP,
-}
+const ospfFull string = "full"
+
+const prefixThreshold string = "THRESH"
+
+// Structured-data events, i.e. the MSGID of an RFC 5424 message.
+const (
+	structuredPrefix   string = "junos@"
+	eventBGPState      string = "BGP_PEER_STATE_CHANGED"
+	eventISISAdjUp     string = "RPD_ISIS_ADJUP"
+	eventISISAdjDown   string = "RPD_ISIS_ADJDOWN"
+	structuredPriority int    = 10
+)
+
+type Parser = types.MessageParser
+
+// Patterns are evaluated in order against each message, and may be extended or overridden.
+var Patterns = types.NewPatterns(
+	types.Pattern{Name: "structured_data", MatchExtra: matchStructured, Parse: ParseStructured, Capture: captureStructured, Priority: structuredPriority, StopOnMatch: true},
+	types.Pattern{Name: "isis", Match: types.RegexpMatcher(regexp.MustCompile(`^(?:RPD_ISIS_\w+: )?IS-IS`)), Parse: ParseISIS, Regexp: patternISIS, StopOnMatch: true},
+	types.Pattern{Name: "bgp", Match: types.RegexpMatcher(regexp.MustCompile(`^(?:BGP_\w+: )?BGP peer`)), Parse: ParseBGP, Regexp: patternBGP, StopOnMatch: true},
+	types.Pattern{Name: "ospf", Match: types.PrefixMatcher("OSPF neighbor"), Parse: ParseOSPF, Regexp: patternOSPF, StopOnMatch: true},
+	types.Pattern{Name: "ospf_event", Match: types.PrefixMatcher("RPD_OSPF_NBR"), Parse: ParseOSPF, Regexp: patternOSPF, StopOnMatch: true},
+	types.Pattern{Name: "bfd_event", Match: types.PrefixMatcher("BFDD_STATE"), Parse: ParseBFD, Regexp: patternBFD, StopOnMatch: true},
+	types.Pattern{Name: "bfd", Match: types.PrefixMatcher("BFD Session"), Parse: ParseBFD, Regexp: patternBFD, StopOnMatch: true},
+	types.Pattern{Name: "bfd_trap", Match: types.PrefixMatcher("bfdd_trap_"), Parse: ParseBFDTrap, Regexp: patternBFDTrap, StopOnMatch: true},
+	types.Pattern{Name: "link", Match: types.PrefixMatcher("SNMP_TRAP_LINK"), Parse: ParseLink, Regexp: patternLink, StopOnMatch: true},
+	types.Pattern{Name: "lacp", Match: types.RegexpMatcher(regexp.MustCompile(`^LACP(?:D_TIMEOUT|_INTF_MUX_STATE_CHANGED):`)), Parse: ParseLACP, Regexp: patternLACP, StopOnMatch: true},
+	types.Pattern{Name: "ldp_event", Match: types.PrefixMatcher("RPD_LDP_SESSION"), Parse: ParseLDP, Regexp: patternLDP, StopOnMatch: true},
+	types.Pattern{Name: "ldp", Match: types.PrefixMatcher("LDP session"), Parse: ParseLDP, Regexp: patternLDP, StopOnMatch: true},
+	types.Pattern{Name: "lsp_event", Match: types.PrefixMatcher("RPD_MPLS_LSP"), Parse: ParseLSP, Regexp: patternLSP, StopOnMatch: true},
+	types.Pattern{Name: "lsp", Match: types.PrefixMatcher("MPLS LSP"), Parse: ParseLSP, Regexp: patternLSP, StopOnMatch: true},
+	types.Pattern{Name: "rsvp_event", Match: types.PrefixMatcher("RPD_RSVP_LSP"), Parse: ParseRSVP, Regexp: patternRSVP, StopOnMatch: true},
+	types.Pattern{Name: "rsvp", Match: types.PrefixMatcher("RSVP LSP"), Parse: ParseRSVP, Regexp: patternRSVP, StopOnMatch: true},
+	types.Pattern{Name: "bgp_notification", Match: types.RegexpMatcher(regexp.MustCompile(`NOTIFICATION (?:sent to|received from) `)), Parse: ParseNotification, Regexp: patternNotification, StopOnMatch: true},
+	types.Pattern{Name: "bgp_prefix_limit", Match: types.PrefixMatcher("BGP_PREFIX_"), Parse: ParsePrefixLimit, Regexp: patternPrefixLimit, StopOnMatch: true},
+)
 
 func ParseISIS(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
 	names := patternISIS.SubexpNames()
 	matches := patternISIS.FindStringSubmatch(msg)
 
 	if len(matches) < isisMinLen || len(matches) > isisMaxLen {
-		return nil, types.ErrIncompleteMatch
+		return nil, types.IncompleteMatchErr(patternISIS, matches)
 	}
 
 	iState := patternISIS.SubexpIndex(names[1])
@@ -65,50 +124,630 @@ func ParseBGP(msg, src string, ts time.Time, extra map[string]any) (types.Log, e
 	names := patternBGP.SubexpNames()
 	matches := patternBGP.FindStringSubmatch(msg)
 	if len(matches) != bgpMinLen {
-		return nil, types.ErrIncompleteMatch
+		return nil, types.IncompleteMatchErr(patternBGP, matches)
 	}
 
 	iRemote := patternBGP.SubexpIndex(names[1])
 	iASN := patternBGP.SubexpIndex(names[2])
-	iState := patternBGP.SubexpIndex(names[3])
-	iTable := patternBGP.SubexpIndex(names[4])
+	iOldState := patternBGP.SubexpIndex(names[3])
+	iState := patternBGP.SubexpIndex(names[4])
+	iEvent := patternBGP.SubexpIndex(names[5])
+	iTable := patternBGP.SubexpIndex(names[6])
 
 	remote := strings.TrimSpace(matches[iRemote])
 	asn := strings.TrimSpace(matches[iASN])
+	oldState := strings.TrimSpace(matches[iOldState])
 	state := strings.TrimSpace(matches[iState])
+	event := strings.TrimSpace(matches[iEvent])
 	table := strings.TrimSpace(matches[iTable])
 
 	l := &types.BGPLog{
-		Base:      types.Base{Type: types.BGP, Original: msg, Extra: extra},
+		Base:             types.Base{Type: types.BGP, Original: msg, Extra: extra},
+		Timestamp:        ts,
+		Local:            src,
+		Remote:           remote,
+		State:            types.DOWN,
+		RemoteAS:         asn,
+		Table:            table,
+		FSMState:         types.ParseBGPState(state),
+		PreviousFSMState: types.ParseBGPState(oldState),
+		Event:            event,
+	}
+	if strings.Contains(strings.ToLower(state), "established") {
+		l.State = types.UP
+	}
+	return l, nil
+}
+
+func ParseOSPF(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	names := patternOSPF.SubexpNames()
+	matches := patternOSPF.FindStringSubmatch(msg)
+	if len(matches) != ospfLen {
+		return nil, types.IncompleteMatchErr(patternOSPF, matches)
+	}
+
+	iRemote := patternOSPF.SubexpIndex(names[1])
+	iRealm := patternOSPF.SubexpIndex(names[2])
+	iIf := patternOSPF.SubexpIndex(names[3])
+	iArea := patternOSPF.SubexpIndex(names[4])
+	iOldState := patternOSPF.SubexpIndex(names[5])
+	iNewState := patternOSPF.SubexpIndex(names[6])
+	iEvent := patternOSPF.SubexpIndex(names[7])
+	iReason := patternOSPF.SubexpIndex(names[8])
+
+	remote := strings.TrimSpace(matches[iRemote])
+	realm := strings.TrimSpace(matches[iRealm])
+	iface := strings.TrimSpace(matches[iIf])
+	area := strings.TrimSpace(matches[iArea])
+	oldState := strings.TrimSpace(matches[iOldState])
+	newState := strings.TrimSpace(matches[iNewState])
+	reason := strings.TrimSpace(matches[iReason])
+
+	if reason == "" {
+		reason = strings.TrimSpace(matches[iEvent])
+	}
+
+	l := &types.OSPFLog{
+		Base:      types.Base{Type: types.OSPF, Original: msg, Extra: extra},
+		Local:     src,
 		Timestamp: ts,
+		Remote:    remote,
+		Interface: iface,
+		Area:      area,
+		OldState:  oldState,
+		NewState:  newState,
+		Reason:    reason,
+		State:     types.DOWN,
+		Version:   2,
+	}
+	if strings.Contains(realm, "v3") || strings.Contains(realm, "ipv6") {
+		l.Version = 3
+	}
+	if strings.ToLower(newState) == ospfFull {
+		l.State = types.UP
+	}
+	return l, nil
+}
+
+func ParseBFD(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	names := patternBFD.SubexpNames()
+	matches := patternBFD.FindStringSubmatch(msg)
+	if len(matches) != bfdLen {
+		return nil, types.IncompleteMatchErr(patternBFD, matches)
+	}
+
+	iRemote := patternBFD.SubexpIndex(names[1])
+	iIf := patternBFD.SubexpIndex(names[2])
+	iState := patternBFD.SubexpIndex(names[4])
+	iLD := patternBFD.SubexpIndex(names[5])
+	iRD := patternBFD.SubexpIndex(names[6])
+	iDiag := patternBFD.SubexpIndex(names[7])
+
+	remote := strings.TrimSpace(matches[iRemote])
+	iface := strings.TrimSpace(matches[iIf])
+	state := strings.TrimSpace(matches[iState])
+	ld := strings.TrimSpace(matches[iLD])
+	rd := strings.TrimSpace(matches[iRD])
+	diag := strings.TrimSpace(matches[iDiag])
+
+	if strings.Contains(strings.ToLower(state), "init") {
+		return nil, nil
+	}
+
+	l := &types.BFDLog{
+		Base:                types.Base{Type: types.BFD, Original: msg, Extra: extra},
+		Local:               src,
+		Timestamp:           ts,
+		Remote:              remote,
+		Interface:           iface,
+		LocalDiscriminator:  ld,
+		RemoteDiscriminator: rd,
+		Diagnostic:          diag,
+		State:               types.DOWN,
+	}
+	if strings.ToLower(state) == "up" {
+		l.State = types.UP
+	}
+	return l, nil
+}
+
+func ParseBFDTrap(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	names := patternBFDTrap.SubexpNames()
+	matches := patternBFDTrap.FindStringSubmatch(msg)
+	if len(matches) != bfdTrapLen {
+		return nil, types.IncompleteMatchErr(patternBFDTrap, matches)
+	}
+
+	iLD := patternBFDTrap.SubexpIndex(names[1])
+	iState := patternBFDTrap.SubexpIndex(names[2])
+	iIf := patternBFDTrap.SubexpIndex(names[3])
+	iRemote := patternBFDTrap.SubexpIndex(names[4])
+
+	ld := strings.TrimSpace(matches[iLD])
+	state := strings.TrimSpace(matches[iState])
+	iface := strings.TrimSpace(matches[iIf])
+	remote := strings.TrimSpace(matches[iRemote])
+
+	l := &types.BFDLog{
+		Base:               types.Base{Type: types.BFD, Original: msg, Extra: extra},
+		Local:              src,
+		Timestamp:          ts,
+		Remote:             remote,
+		Interface:          iface,
+		LocalDiscriminator: ld,
+		State:              types.DOWN,
+	}
+	if strings.ToLower(state) == "up" {
+		l.State = types.UP
+	}
+	return l, nil
+}
+
+func ParseLink(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	names := patternLink.SubexpNames()
+	matches := patternLink.FindStringSubmatch(msg)
+	if len(matches) != linkLen {
+		return nil, types.IncompleteMatchErr(patternLink, matches)
+	}
+
+	iIfIndex := patternLink.SubexpIndex(names[1])
+	iAdmin := patternLink.SubexpIndex(names[2])
+	iOper := patternLink.SubexpIndex(names[3])
+	iIf := patternLink.SubexpIndex(names[4])
+
+	ifIndex := strings.TrimSpace(matches[iIfIndex])
+	admin := strings.TrimSpace(matches[iAdmin])
+	oper := strings.TrimSpace(matches[iOper])
+	iface := strings.TrimSpace(matches[iIf])
+
+	l := &types.InterfaceLog{
+		Base:        types.Base{Type: types.Interface, Original: msg, Extra: extra},
+		Local:       src,
+		Timestamp:   ts,
+		Interface:   iface,
+		IfIndex:     ifIndex,
+		AdminStatus: admin,
+		OperStatus:  oper,
+		State:       types.DOWN,
+	}
+	if strings.ToLower(oper) == "up" {
+		l.State = types.UP
+	}
+	return l, nil
+}
+
+func ParseLACP(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	names := patternLACP.SubexpNames()
+	matches := patternLACP.FindStringSubmatch(msg)
+	if len(matches) != lacpLen {
+		return nil, types.IncompleteMatchErr(patternLACP, matches)
+	}
+
+	iIf := patternLACP.SubexpIndex(names[1])
+	iReason := patternLACP.SubexpIndex(names[2])
+	iParent := patternLACP.SubexpIndex(names[3])
+	iMember := patternLACP.SubexpIndex(names[4])
+	iChange := patternLACP.SubexpIndex(names[5])
+	iMuxState := patternLACP.SubexpIndex(names[6])
+
+	iface := strings.TrimSpace(matches[iIf])
+	reason := strings.TrimSpace(matches[iReason])
+	parent := strings.TrimSpace(matches[iParent])
+	muxState := strings.TrimSpace(matches[iMuxState])
+
+	l := &types.InterfaceLog{
+		Base:       types.Base{Type: types.Interface, Original: msg, Extra: extra},
+		Local:      src,
+		Timestamp:  ts,
+		Interface:  iface,
+		OperStatus: "down",
+		Parent:     parent,
+		Reason:     reason,
+		State:      types.DOWN,
+	}
+	// A timeout always takes the member out of its bundle, whereas a mux state change names the
+	// bundle and member, which only forwards traffic once it is distributing.
+	if parent != "" {
+		l.Interface = strings.TrimSpace(matches[iMember])
+		l.Reason = strings.TrimSpace(matches[iChange])
+		if strings.HasSuffix(muxState, "DISTRIBUTING") {
+			l.OperStatus = "up"
+			l.State = types.UP
+		}
+	}
+	return l, nil
+}
+
+func ParseLDP(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	names := patternLDP.SubexpNames()
+	matches := patternLDP.FindStringSubmatch(msg)
+	if len(matches) != ldpLen {
+		return nil, types.IncompleteMatchErr(patternLDP, matches)
+	}
+
+	iSession := patternLDP.SubexpIndex(names[1])
+	iState := patternLDP.SubexpIndex(names[2])
+	iReason := patternLDP.SubexpIndex(names[3])
+
+	session := strings.TrimSpace(matches[iSession])
+	state := strings.TrimSpace(matches[iState])
+	reason := strings.TrimSpace(matches[iReason])
+
+	// LDP session identifiers may carry the label space, e.g. 10.255.0.2:0.
+	remote, _, _ := strings.Cut(session, ":")
+
+	l := &types.LDPLog{
+		Base:      types.Base{Type: types.LDP, Original: msg, Extra: extra},
 		Local:     src,
+		Timestamp: ts,
 		Remote:    remote,
+		Session:   session,
+		Reason:    reason,
+		State:     types.DOWN,
+	}
+	if state == "up" {
+		l.State = types.UP
+	}
+	return l, nil
+}
+
+func ParseLSP(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	names := patternLSP.SubexpNames()
+	matches := patternLSP.FindStringSubmatch(msg)
+	if len(matches) != lspLen {
+		return nil, types.IncompleteMatchErr(patternLSP, matches)
+	}
+
+	iLSP := patternLSP.SubexpIndex(names[1])
+	iState := patternLSP.SubexpIndex(names[2])
+	iPath := patternLSP.SubexpIndex(names[3])
+	iReason := patternLSP.SubexpIndex(names[5])
+
+	lsp := strings.TrimSpace(matches[iLSP])
+	state := strings.TrimSpace(matches[iState])
+	path := strings.TrimSpace(matches[iPath])
+	reason := strings.TrimSpace(matches[iReason])
+
+	// A change reroutes an LSP that is already up, so it's skipped rather than reported as up.
+	if state == "change" {
+		return nil, nil
+	}
+
+	// MPLS LSP messages are only logged by the LSP's ingress, which is the source. They don't name
+	// the egress, and only some of them include the route, so Egress is left empty to keep the ID
+	// of every event of an LSP the same.
+	l := &types.RSVPLog{
+		Base:      types.Base{Type: types.RSVP, Original: msg, Extra: extra},
+		Local:     src,
+		Timestamp: ts,
+		LSP:       lsp,
+		Ingress:   src,
+		Path:      path,
+		Reason:    reason,
 		State:     types.DOWN,
+	}
+	if state == "up" {
+		l.State = types.UP
+	}
+	return l, nil
+}
+
+func ParseRSVP(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	names := patternRSVP.SubexpNames()
+	matches := patternRSVP.FindStringSubmatch(msg)
+	if len(matches) != rsvpLen {
+		return nil, types.IncompleteMatchErr(patternRSVP, matches)
+	}
+
+	iLSP := patternRSVP.SubexpIndex(names[1])
+	iIngress := patternRSVP.SubexpIndex(names[2])
+	iEgress := patternRSVP.SubexpIndex(names[3])
+	iState := patternRSVP.SubexpIndex(names[4])
+	iReason := patternRSVP.SubexpIndex(names[5])
+
+	lsp := strings.TrimSpace(matches[iLSP])
+	ingress := strings.TrimSpace(matches[iIngress])
+	egress := strings.TrimSpace(matches[iEgress])
+	state := strings.TrimSpace(matches[iState])
+	reason := strings.TrimSpace(matches[iReason])
+
+	l := &types.RSVPLog{
+		Base:      types.Base{Type: types.RSVP, Original: msg, Extra: extra},
+		Local:     src,
+		Timestamp: ts,
+		LSP:       lsp,
+		Ingress:   ingress,
+		Egress:    egress,
+		Reason:    reason,
+		State:     types.DOWN,
+	}
+	if state == "up" {
+		l.State = types.UP
+	}
+	return l, nil
+}
+
+func ParseNotification(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	names := patternNotification.SubexpNames()
+	matches := patternNotification.FindStringSubmatch(msg)
+	if len(matches) != notifyLen {
+		return nil, types.IncompleteMatchErr(patternNotification, matches)
+	}
+
+	iDirection := patternNotification.SubexpIndex(names[1])
+	iRemote := patternNotification.SubexpIndex(names[2])
+	iASN := patternNotification.SubexpIndex(names[3])
+	iTable := patternNotification.SubexpIndex(names[4])
+	iCode := patternNotification.SubexpIndex(names[5])
+	iCodeText := patternNotification.SubexpIndex(names[6])
+	iSubcode := patternNotification.SubexpIndex(names[7])
+	iSubcodeText := patternNotification.SubexpIndex(names[8])
+
+	direction := strings.TrimSpace(matches[iDirection])
+	remote := strings.TrimSpace(matches[iRemote])
+	asn := strings.TrimSpace(matches[iASN])
+	table := strings.TrimSpace(matches[iTable])
+	code, _ := strconv.ParseUint(matches[iCode], 10, 0)
+	subcode, _ := strconv.ParseUint(matches[iSubcode], 10, 0)
+	text := strings.TrimSpace(matches[iCodeText])
+
+	if subcodeText := strings.TrimSpace(matches[iSubcodeText]); subcodeText != "" {
+		text = text + "/" + subcodeText
+	}
+
+	// NOTIFICATION messages only name the instance outside of master, so default to it in order to
+	// share an ID with the BGPLog of the same session.
+	if table == "" {
+		table = defaultInstance
+	}
+
+	l := &types.BGPNotificationLog{
+		Base:      types.Base{Type: types.BGPNotification, Original: msg, Extra: extra},
+		Timestamp: ts,
+		Local:     src,
+		Remote:    remote,
 		RemoteAS:  asn,
 		Table:     table,
+		Received:  direction == received,
+		Code:      uint(code),
+		Subcode:   uint(subcode),
+		Text:      text,
 	}
-	if strings.Contains(strings.ToLower(state), "established") {
-		l.State = types.UP
+	return l, nil
+}
+
+func ParsePrefixLimit(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	names := patternPrefixLimit.SubexpNames()
+	matches := patternPrefixLimit.FindStringSubmatch(msg)
+	if len(matches) != prefixLen {
+		return nil, types.IncompleteMatchErr(patternPrefixLimit, matches)
+	}
+
+	iKind := patternPrefixLimit.SubexpIndex(names[1])
+	iRemote := patternPrefixLimit.SubexpIndex(names[2])
+	iASN := patternPrefixLimit.SubexpIndex(names[3])
+	iLimit := patternPrefixLimit.SubexpIndex(names[4])
+	iAFISAFI := patternPrefixLimit.SubexpIndex(names[5])
+	iCount := patternPrefixLimit.SubexpIndex(names[6])
+	iTable := patternPrefixLimit.SubexpIndex(names[7])
+	iTeardown := patternPrefixLimit.SubexpIndex(names[8])
+
+	kind := strings.TrimSpace(matches[iKind])
+	remote := strings.TrimSpace(matches[iRemote])
+	asn := strings.TrimSpace(matches[iASN])
+	afiSafi := strings.TrimSpace(matches[iAFISAFI])
+	table := strings.TrimSpace(matches[iTable])
+	limit, _ := strconv.ParseUint(matches[iLimit], 10, 0)
+	count, _ := strconv.ParseUint(matches[iCount], 10, 0)
+
+	if table == "" {
+		table = defaultInstance
+	}
+
+	threshold := kind == prefixThreshold
+
+	l := &types.BGPPrefixLimitLog{
+		Base:      types.Base{Type: types.BGPPrefixLimit, Original: msg, Extra: extra},
+		Timestamp: ts,
+		Local:     src,
+		Remote:    remote,
+		RemoteAS:  asn,
+		Table:     table,
+		AFISAFI:   afiSafi,
+		Limit:     uint(limit),
+		Count:     uint(count),
+		Threshold: threshold,
+		// Whether the session is torn down when the limit is exceeded depends on the peer's
+		// configuration, so it's only assumed when the message says so.
+		TornDown: matches[iTeardown] != "",
 	}
 	return l, nil
 }
 
-func Parse(req *types.Request) ([]types.Log, error) {
-	logs := make([]types.Log, 0, len(req.Messages))
-	for _, msg := range req.Messages {
-		for prefix, parser := range parseMap {
-			if strings.HasPrefix(msg, prefix) {
-				l, err := parser(msg, req.Source, req.Timestamp, req.Extra)
-				if err != nil {
-					return nil, err
+type structuredParser func(event string, params map[string]string, msg, src string, ts time.Time, extra map[string]any) (types.Log, error)
+
+var structuredMap = map[string]structuredParser{
+	eventBGPState:    parseStructuredBGP,
+	eventISISAdjUp:   parseStructuredISIS,
+	eventISISAdjDown: parseStructuredISIS,
+}
+
+// structured returns the event name and junos@ structured-data parameters of a message, either
+// from structured data left in the message text, or from the msgid and structured data a syslog
+// parser put in extra. It only succeeds for events that can be parsed.
+func structured(msg string, extra map[string]any) (string, map[string]string, bool) {
+	var event string
+	var sd map[string]map[string]string
+	if matches := patternStructured.FindStringSubmatch(msg); matches != nil {
+		parsed, _, err := syslog.ParseStructuredData(matches[patternStructured.SubexpIndex("sd")])
+		if err != nil {
+			return "", nil, false
+		}
+		event = matches[patternStructured.SubexpIndex("event")]
+		sd = parsed
+	} else {
+		event, _ = extra[syslog.ExtraMsgID].(string)
+		sd = structuredData(extra[syslog.ExtraStructuredData])
+	}
+	if _, ok := structuredMap[event]; !ok {
+		return "", nil, false
+	}
+	for id, params := range sd {
+		if strings.HasPrefix(id, structuredPrefix) {
+			return event, params, true
+		}
+	}
+	return "", nil, false
+}
+
+// structuredData converts structured data from extra, which is untyped once it has been through
+// JSON, to its syslog package form.
+func structuredData(value any) map[string]map[string]string {
+	switch sd := value.(type) {
+	case map[string]map[string]string:
+		return sd
+	case map[string]any:
+		converted := make(map[string]map[string]string, len(sd))
+		for id, rawParams := range sd {
+			params, ok := rawParams.(map[string]any)
+			if !ok {
+				continue
+			}
+			converted[id] = make(map[string]string, len(params))
+			for name, value := range params {
+				if s, ok := value.(string); ok {
+					converted[id][name] = s
 				}
-				logs = append(logs, l)
 			}
 		}
+		return converted
 	}
+	return nil
+}
 
-	if len(logs) != 0 {
-		return logs, nil
+func matchStructured(msg string, extra map[string]any) bool {
+	_, _, ok := structured(msg, extra)
+	return ok
+}
+
+// captureStructured explains a structured-data event with its event name and junos@ parameters,
+// which may not be in the message text.
+func captureStructured(msg string, extra map[string]any) map[string]string {
+	event, params, ok := structured(msg, extra)
+	if !ok {
+		return nil
+	}
+	captures := make(map[string]string, len(params)+1)
+	for name, value := range params {
+		captures[name] = value
+	}
+	captures["event"] = event
+	return captures
+}
+
+func firstParam(params map[string]string, names ...string) string {
+	for _, name := range names {
+		if value := strings.TrimSpace(params[name]); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// ParseStructured parses an event from its junos@ structured data rather than its text, which
+// is available when routers are configured with structured-data syslog.
+func ParseStructured(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	event, params, ok := structured(msg, extra)
+	if !ok {
+		return nil, types.MissingGroupsErr(syslog.ExtraStructuredData)
+	}
+	return structuredMap[event](event, params, msg, src, ts, extra)
+}
+
+func parseStructuredBGP(event string, params map[string]string, msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	peer := patternStructuredPeer.FindStringSubmatch(firstParam(params, "peer-name"))
+	state := firstParam(params, "new-state")
+	if peer == nil || state == "" {
+		missing := make([]string, 0)
+		if peer == nil {
+			missing = append(missing, "peer-name")
+		}
+		if state == "" {
+			missing = append(missing, "new-state")
+		}
+		return nil, types.MissingGroupsErr(missing...)
+	}
+
+	asn := peer[patternStructuredPeer.SubexpIndex("remote_as")]
+	if asn == "" {
+		asn = firstParam(params, "peer-as", "remote-as")
+	}
+	oldState := firstParam(params, "old-state")
+	table := firstParam(params, "instance", "routing-instance")
+	if table == "" {
+		table = defaultInstance
+	}
+
+	l := &types.BGPLog{
+		Base:             types.Base{Type: types.BGP, Original: msg, Extra: extra},
+		Timestamp:        ts,
+		Local:            src,
+		Remote:           peer[patternStructuredPeer.SubexpIndex("remote")],
+		State:            types.DOWN,
+		RemoteAS:         asn,
+		Table:            table,
+		FSMState:         types.ParseBGPState(state),
+		PreviousFSMState: types.ParseBGPState(oldState),
+		Event:            firstParam(params, "event-name"),
 	}
-	return nil, types.ErrNoMatchingParser
+	if l.FSMState == types.ESTABLISHED {
+		l.State = types.UP
+	}
+	return l, nil
+}
+
+func parseStructuredISIS(event string, params map[string]string, msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	remote := firstParam(params, "nbr-name", "neighbor-name", "isis-neighbor")
+	iface := firstParam(params, "interface-name", "ifl-name")
+	if remote == "" || iface == "" {
+		missing := make([]string, 0)
+		if remote == "" {
+			missing = append(missing, "nbr-name")
+		}
+		if iface == "" {
+			missing = append(missing, "interface-name")
+		}
+		return nil, types.MissingGroupsErr(missing...)
+	}
+
+	l := &types.ISISLog{
+		Base:      types.Base{Type: types.ISIS, Original: msg, Extra: extra},
+		Local:     src,
+		Timestamp: ts,
+		Remote:    remote,
+		Interface: iface,
+		Reason:    firstParam(params, "reason", "error-name"),
+		State:     types.DOWN,
+	}
+	if event == eventISISAdjUp {
+		l.State = types.UP
+	}
+	return l, nil
+}
+
+// Platform is the name this package's parser is registered under.
+const Platform string = "junos"
+
+func init() {
+	types.RegisterPatterns(Platform, Patterns)
+	types.RegisterHints(Platform, "rpd", "bfdd", "mib2d", "lacpd", "ppmd")
+}
+
+func Parse(req *types.Request) ([]types.Log, error) {
+	return Patterns.Parse(req)
+}
+
+// ParseAll parses every message of a request it can, recording the outcome of each message.
+func ParseAll(req *types.Request) *types.Result {
+	return Patterns.ParseAll(req)
 }
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stellaraf/go-parselog/junos"
+	"github.com/stellaraf/go-parselog/syslog"
 	"github.com/stellaraf/go-parselog/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -59,6 +60,9 @@ func Test_ParseBGP(t *testing.T) {
 		assert.Equal(t, "2604:c0c0:3000::13e2", attrs["remote"])
 		assert.Equal(t, "14525", attrs["remote_as"])
 		assert.Equal(t, "master", attrs["table"])
+		assert.Equal(t, types.ESTABLISHED, attrs["fsm_state"])
+		assert.Equal(t, types.OPENCONFIRM, attrs["previous_fsm_state"])
+		assert.Equal(t, "RecvKeepAlive", attrs["event"])
 		assert.Equal(t, msg, attrs["original"])
 		assert.False(t, result.Down())
 		assert.True(t, result.Up())
@@ -73,10 +77,23 @@ func Test_ParseBGP(t *testing.T) {
 		assert.Equal(t, "2604:c0c0:3000::13e2", attrs["remote"])
 		assert.Equal(t, "14525", attrs["remote_as"])
 		assert.Equal(t, "master", attrs["table"])
+		assert.Equal(t, types.IDLE, attrs["fsm_state"])
+		assert.Equal(t, types.ESTABLISHED, attrs["previous_fsm_state"])
+		assert.Equal(t, "RecvNotify", attrs["event"])
 		assert.Equal(t, msg, attrs["original"])
 		assert.False(t, result.Up())
 		assert.True(t, result.Down())
 	})
+	t.Run("connect to active", func(t *testing.T) {
+		t.Parallel()
+		msg := "BGP peer 2604:c0c0:3000::13e2 (Internal AS 14525) changed state from Connect to Active (event ConnectRetry) (instance master)"
+		result, err := junos.ParseBGP(msg, "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.ACTIVE, attrs["fsm_state"])
+		assert.Equal(t, types.CONNECT, attrs["previous_fsm_state"])
+		assert.True(t, result.Down())
+	})
 	t.Run("missing fields", func(t *testing.T) {
 		t.Parallel()
 		_, err := junos.ParseBGP("BGP peer 2604:c0c0:3000::13e2 (Internal AS 14525)", "", time.Now(), nil)
@@ -84,7 +101,518 @@ func Test_ParseBGP(t *testing.T) {
 	})
 }
 
+func Test_ParseOSPF(t *testing.T) {
+	t.Run("up", func(t *testing.T) {
+		t.Parallel()
+		msg := "RPD_OSPF_NBRUP: OSPF neighbor 10.0.0.2 (realm ospf-v2 ge-0/0/0.0 area 0.0.0.0) state changed from Loading to Full due to LoadDone (event reason: OSPF loadDone)"
+		result, err := junos.ParseOSPF(msg, "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.UP, attrs["state"])
+		assert.Equal(t, "10.0.0.2", attrs["remote"])
+		assert.Equal(t, "ge-0/0/0.0", attrs["interface"])
+		assert.Equal(t, "0.0.0.0", attrs["area"])
+		assert.Equal(t, "Loading", attrs["old_state"])
+		assert.Equal(t, "Full", attrs["new_state"])
+		assert.Equal(t, "OSPF loadDone", attrs["reason"])
+		assert.Equal(t, uint(2), attrs["version"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Down())
+		assert.True(t, result.Up())
+	})
+	t.Run("down", func(t *testing.T) {
+		t.Parallel()
+		msg := "OSPF neighbor 10.0.0.2 (realm ospf-v2 ae0.3613 area 0.0.0.10) state changed from Full to Down due to KillNbr (event reason: interface went down)"
+		result, err := junos.ParseOSPF(msg, "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.DOWN, attrs["state"])
+		assert.Equal(t, "ae0.3613", attrs["interface"])
+		assert.Equal(t, "0.0.0.10", attrs["area"])
+		assert.Equal(t, "Full", attrs["old_state"])
+		assert.Equal(t, "Down", attrs["new_state"])
+		assert.Equal(t, "interface went down", attrs["reason"])
+		assert.False(t, result.Up())
+		assert.True(t, result.Down())
+	})
+	t.Run("v3", func(t *testing.T) {
+		t.Parallel()
+		msg := "RPD_OSPF_NBRDOWN: OSPF neighbor fe80::1 (realm ipv6-unicast ae0.3613 area 0.0.0.0) state changed from Full to Init due to 1WayRcvd"
+		result, err := junos.ParseOSPF(msg, "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, uint(3), attrs["version"])
+		assert.Equal(t, "fe80::1", attrs["remote"])
+		assert.Equal(t, "1WayRcvd", attrs["reason"])
+		assert.True(t, result.Down())
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := junos.ParseOSPF("OSPF neighbor 10.0.0.2 (realm ospf-v2 ge-0/0/0.0 area 0.0.0.0)", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_ParseBFD(t *testing.T) {
+	t.Run("down", func(t *testing.T) {
+		t.Parallel()
+		msg := "BFDD_STATE_UP_TO_DOWN: BFD Session 10.0.0.2 (IFL 345) state Up -> Down LD/RD(16/17) Up time:1d 02:03 Local diag: CtlExpire Remote diag: None Reason: Detect Timer Expiry."
+		result, err := junos.ParseBFD(msg, "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.DOWN, attrs["state"])
+		assert.Equal(t, "10.0.0.2", attrs["remote"])
+		assert.Equal(t, "345", attrs["interface"])
+		assert.Equal(t, "16", attrs["local_discriminator"])
+		assert.Equal(t, "17", attrs["remote_discriminator"])
+		assert.Equal(t, "CtlExpire", attrs["diagnostic"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Up())
+		assert.True(t, result.Down())
+	})
+	t.Run("no diag", func(t *testing.T) {
+		t.Parallel()
+		msg := "BFD Session 10.0.0.2 (IFL 345) state Down -> Up LD/RD(16/17)"
+		result, err := junos.ParseBFD(msg, "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Empty(t, attrs["diagnostic"])
+		assert.True(t, result.Up())
+	})
+	t.Run("init", func(t *testing.T) {
+		t.Parallel()
+		result, err := junos.ParseBFD("BFDD_STATE_DOWN_TO_INIT: BFD Session 10.0.0.2 (IFL 345) state Down -> Init LD/RD(16/17)", "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := junos.ParseBFD("BFDD_STATE_UP_TO_DOWN: BFD Session 10.0.0.2 (IFL 345) state Up -> Down", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_ParseBFDTrap(t *testing.T) {
+	t.Run("down", func(t *testing.T) {
+		t.Parallel()
+		msg := "bfdd_trap_shop_state_down: local discriminator: 16, new state: down, interface: ae0.3613, peer addr: 10.0.0.2"
+		result, err := junos.ParseBFDTrap(msg, "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.DOWN, attrs["state"])
+		assert.Equal(t, "10.0.0.2", attrs["remote"])
+		assert.Equal(t, "ae0.3613", attrs["interface"])
+		assert.Equal(t, "16", attrs["local_discriminator"])
+		assert.Empty(t, attrs["remote_discriminator"])
+		assert.True(t, result.Down())
+	})
+	t.Run("up", func(t *testing.T) {
+		t.Parallel()
+		msg := "bfdd_trap_shop_state_up: local discriminator: 16, new state: up, interface: ae0.3613, peer addr: 10.0.0.2"
+		result, err := junos.ParseBFDTrap(msg, "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		assert.True(t, result.Up())
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := junos.ParseBFDTrap("bfdd_trap_shop_state_down: local discriminator: 16", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_ParseLink(t *testing.T) {
+	t.Run("down", func(t *testing.T) {
+		t.Parallel()
+		msg := "SNMP_TRAP_LINK_DOWN: ifIndex 526, ifAdminStatus up(1), ifOperStatus down(2), ifName xe-0/0/1"
+		result, err := junos.ParseLink(msg, "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.DOWN, attrs["state"])
+		assert.Equal(t, "xe-0/0/1", attrs["interface"])
+		assert.Equal(t, "526", attrs["if_index"])
+		assert.Equal(t, "up", attrs["admin_status"])
+		assert.Equal(t, "down", attrs["oper_status"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.True(t, result.Down())
+	})
+	t.Run("up", func(t *testing.T) {
+		t.Parallel()
+		msg := "SNMP_TRAP_LINK_UP: ifIndex 526, ifAdminStatus up(1), ifOperStatus up(1), ifName xe-0/0/1"
+		result, err := junos.ParseLink(msg, "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		assert.True(t, result.Up())
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := junos.ParseLink("SNMP_TRAP_LINK_DOWN: ifIndex 526", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_ParseLACP(t *testing.T) {
+	t.Run("timeout", func(t *testing.T) {
+		t.Parallel()
+		msg := "LACPD_TIMEOUT: xe-0/0/1: lacp current while timer expired current Receive State: CURRENT"
+		result, err := junos.ParseLACP(msg, "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.DOWN, attrs["state"])
+		assert.Equal(t, "xe-0/0/1", attrs["interface"])
+		assert.Equal(t, "lacp current while timer expired current Receive State: CURRENT", attrs["reason"])
+		assert.Equal(t, "down", attrs["oper_status"])
+		assert.True(t, result.Down())
+	})
+	t.Run("mux state up", func(t *testing.T) {
+		t.Parallel()
+		msg := "LACP_INTF_MUX_STATE_CHANGED: ae0: xe-0/0/1: Lacp state changed from ATTACHED to COLLECTING_DISTRIBUTING, actor port state : |-|-|DIS|COL|IN_SYNC|AGG|SHORT|ACT|, partner port state : |-|-|DIS|COL|IN_SYNC|AGG|SHORT|ACT|"
+		result, err := junos.ParseLACP(msg, "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, "xe-0/0/1", attrs["interface"])
+		assert.Equal(t, "ae0", attrs["parent"])
+		assert.Equal(t, "up", attrs["oper_status"])
+		assert.Equal(t, "Lacp state changed from ATTACHED to COLLECTING_DISTRIBUTING", attrs["reason"])
+		assert.True(t, result.Up())
+	})
+	t.Run("mux state down", func(t *testing.T) {
+		t.Parallel()
+		msg := "LACP_INTF_MUX_STATE_CHANGED: ae0: xe-0/0/1: Lacp state changed from COLLECTING_DISTRIBUTING to ATTACHED, actor port state : |EXP|-|-|-|IN_SYNC|AGG|SHORT|ACT|, partner port state : |-|-|DIS|COL|OUT_OF_SYNC|AGG|SHORT|ACT|"
+		result, err := junos.ParseLACP(msg, "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, "ae0", attrs["parent"])
+		assert.Equal(t, "down", attrs["oper_status"])
+		assert.True(t, result.Down())
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := junos.ParseLACP("LACPD_TIMEOUT: xe-0/0/1", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_ParseLDP(t *testing.T) {
+	t.Run("down", func(t *testing.T) {
+		t.Parallel()
+		msg := "RPD_LDP_SESSIONDOWN: LDP session 10.255.0.2:0 is down, reason: received notification from peer"
+		result, err := junos.ParseLDP(msg, "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.DOWN, attrs["state"])
+		assert.Equal(t, "10.255.0.2", attrs["remote"])
+		assert.Equal(t, "10.255.0.2:0", attrs["session"])
+		assert.Equal(t, "received notification from peer", attrs["reason"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.True(t, result.Down())
+	})
+	t.Run("up", func(t *testing.T) {
+		t.Parallel()
+		msg := "RPD_LDP_SESSIONUP: LDP session 10.255.0.2 (instance master) is up"
+		result, err := junos.ParseLDP(msg, "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, "10.255.0.2", attrs["remote"])
+		assert.Empty(t, attrs["reason"])
+		assert.True(t, result.Up())
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := junos.ParseLDP("RPD_LDP_SESSIONDOWN: LDP session 10.255.0.2", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_ParseLSP(t *testing.T) {
+	t.Run("up", func(t *testing.T) {
+		t.Parallel()
+		msg := "RPD_MPLS_LSP_UP: MPLS LSP er01-to-er02 up on primary(via-hnl01) Route  10.0.0.2 10.0.1.2"
+		result, err := junos.ParseLSP(msg, "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.UP, attrs["state"])
+		assert.Equal(t, "er01-to-er02", attrs["lsp"])
+		assert.Equal(t, "via-hnl01", attrs["path"])
+		assert.Equal(t, "er01.gvl01.as14525.net", attrs["ingress"])
+		assert.Empty(t, attrs["egress"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.True(t, result.Up())
+	})
+	t.Run("up and down share id", func(t *testing.T) {
+		t.Parallel()
+		up, err := junos.ParseLSP("RPD_MPLS_LSP_UP: MPLS LSP er01-to-er02 up on primary(via-hnl01) Route  10.0.0.2 10.0.1.2", "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		down, err := junos.ParseLSP("RPD_MPLS_LSP_DOWN: MPLS LSP er01-to-er02 down on primary(via-hnl01), Reason: session preempted", "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, up.ID(), down.ID())
+		other, err := junos.ParseLSP("RPD_MPLS_LSP_DOWN: MPLS LSP er01-to-er03 down on primary(via-hnl01)", "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		assert.NotEqual(t, up.ID(), other.ID())
+	})
+	t.Run("same name from another ingress", func(t *testing.T) {
+		t.Parallel()
+		msg := "RPD_MPLS_LSP_UP: MPLS LSP to-core up on primary(via-hnl01)"
+		er01, err := junos.ParseLSP(msg, "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		er02, err := junos.ParseLSP(msg, "er02.hnl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		assert.NotEqual(t, er01.ID(), er02.ID())
+	})
+	t.Run("change", func(t *testing.T) {
+		t.Parallel()
+		msg := "RPD_MPLS_LSP_CHANGE: MPLS LSP er01-to-er02 change on primary(via-hnl01) Route  10.0.0.6 10.0.1.2"
+		result, err := junos.ParseLSP(msg, "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("down", func(t *testing.T) {
+		t.Parallel()
+		msg := "RPD_MPLS_LSP_DOWN: MPLS LSP er01-to-er02 down on primary(via-hnl01), Reason: session preempted"
+		result, err := junos.ParseLSP(msg, "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Empty(t, attrs["egress"])
+		assert.Equal(t, "session preempted", attrs["reason"])
+		assert.True(t, result.Down())
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := junos.ParseLSP("RPD_MPLS_LSP_DOWN: MPLS LSP er01-to-er02 down", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_ParseRSVP(t *testing.T) {
+	t.Run("down", func(t *testing.T) {
+		t.Parallel()
+		msg := "RPD_RSVP_LSPDOWN: RSVP LSP er01-to-er02 from 10.255.0.1 to 10.255.0.2 is down, reason: PathErr received"
+		result, err := junos.ParseRSVP(msg, "er03.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.DOWN, attrs["state"])
+		assert.Equal(t, "er01-to-er02", attrs["lsp"])
+		assert.Equal(t, "10.255.0.1", attrs["ingress"])
+		assert.Equal(t, "10.255.0.2", attrs["egress"])
+		assert.Equal(t, "PathErr received", attrs["reason"])
+		assert.True(t, result.Down())
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := junos.ParseRSVP("RPD_RSVP_LSPDOWN: RSVP LSP er01-to-er02 is down", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_ParseNotification(t *testing.T) {
+	t.Run("received", func(t *testing.T) {
+		t.Parallel()
+		msg := "bgp_recv_notification: NOTIFICATION received from 2604:c0c0:3000::13e2 (Internal AS 14525): code 6 (Cease) subcode 2 (Administrative Shutdown)"
+		result, err := junos.ParseNotification(msg, "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, "2604:c0c0:3000::13e2", attrs["remote"])
+		assert.Equal(t, "14525", attrs["remote_as"])
+		assert.Equal(t, "master", attrs["table"])
+		assert.Equal(t, true, attrs["received"])
+		assert.Equal(t, uint(6), attrs["code"])
+		assert.Equal(t, uint(2), attrs["subcode"])
+		assert.Equal(t, "Cease/Administrative Shutdown", attrs["text"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.True(t, result.Down())
+	})
+	t.Run("sent", func(t *testing.T) {
+		t.Parallel()
+		msg := "bgp_pp_recv:3950: NOTIFICATION sent to 10.0.0.2 (External AS 65000) (instance CUST-A): code 4 (Hold Timer Expired Error), Reason: holdtime expired for 10.0.0.2"
+		result, err := junos.ParseNotification(msg, "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, "CUST-A", attrs["table"])
+		assert.Equal(t, false, attrs["received"])
+		assert.Equal(t, uint(4), attrs["code"])
+		assert.Equal(t, uint(0), attrs["subcode"])
+		assert.Equal(t, "Hold Timer Expired Error", attrs["text"])
+	})
+	t.Run("shares session id", func(t *testing.T) {
+		t.Parallel()
+		notification, err := junos.ParseNotification("NOTIFICATION received from 2604:c0c0:3000::13e2 (Internal AS 14525): code 6 (Cease) subcode 2 (Administrative Shutdown)", "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		session, err := junos.ParseBGP("BGP peer 2604:c0c0:3000::13e2 (Internal AS 14525) changed state from Established to Idle (event RecvNotify) (instance master)", "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, session.ID(), notification.ID())
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := junos.ParseNotification("NOTIFICATION received from 10.0.0.2 (External AS 65000)", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_ParsePrefixLimit(t *testing.T) {
+	t.Run("threshold", func(t *testing.T) {
+		t.Parallel()
+		msg := "BGP_PREFIX_THRESH_EXCEEDED: 2604:c0c0:3000::13e2 (External AS 65000): Configured maximum prefix-limit threshold(80) exceeded for inet6-unicast nlri: 81 (instance CUST-A)"
+		result, err := junos.ParsePrefixLimit(msg, "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, "2604:c0c0:3000::13e2", attrs["remote"])
+		assert.Equal(t, "65000", attrs["remote_as"])
+		assert.Equal(t, "CUST-A", attrs["table"])
+		assert.Equal(t, "inet6-unicast", attrs["afi_safi"])
+		assert.Equal(t, uint(80), attrs["limit"])
+		assert.Equal(t, uint(81), attrs["count"])
+		assert.Equal(t, true, attrs["threshold"])
+		assert.Equal(t, false, attrs["torn_down"])
+		assert.False(t, result.Down())
+	})
+	t.Run("limit", func(t *testing.T) {
+		t.Parallel()
+		msg := "BGP_PREFIX_LIMIT_EXCEEDED: 10.0.0.2 (External AS 65000): Configured maximum prefix-limit(100) exceeded for inet-unicast nlri: 101"
+		result, err := junos.ParsePrefixLimit(msg, "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, "master", attrs["table"])
+		assert.Equal(t, "inet-unicast", attrs["afi_safi"])
+		assert.Equal(t, uint(100), attrs["limit"])
+		assert.Equal(t, uint(101), attrs["count"])
+		assert.Equal(t, false, attrs["threshold"])
+		assert.Equal(t, false, attrs["torn_down"])
+		assert.False(t, result.Down())
+	})
+	t.Run("torn down", func(t *testing.T) {
+		t.Parallel()
+		msg := "BGP_PREFIX_LIMIT_EXCEEDED: 10.0.0.2 (External AS 65000): Configured maximum prefix-limit(100) exceeded for inet-unicast nlri: 101 (instance master), peer torn down"
+		result, err := junos.ParsePrefixLimit(msg, "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, "master", attrs["table"])
+		assert.Equal(t, true, attrs["torn_down"])
+		assert.True(t, result.Down())
+	})
+	t.Run("shares session id", func(t *testing.T) {
+		t.Parallel()
+		limit, err := junos.ParsePrefixLimit("BGP_PREFIX_LIMIT_EXCEEDED: 2604:c0c0:3000::13e2 (Internal AS 14525): Configured maximum prefix-limit(100) exceeded for inet6-unicast nlri: 101 (instance master)", "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		session, err := junos.ParseBGP("BGP peer 2604:c0c0:3000::13e2 (Internal AS 14525) changed state from Established to Idle (event Stop) (instance master)", "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, session.ID(), limit.ID())
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := junos.ParsePrefixLimit("BGP_PREFIX_LIMIT_EXCEEDED: 10.0.0.2 (External AS 65000)", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_ParseStructured(t *testing.T) {
+	t.Run("bgp in message", func(t *testing.T) {
+		t.Parallel()
+		msg := `BGP_PEER_STATE_CHANGED [junos@2636.1.1.1.2.29 peer-name="10.0.0.2 (External AS 65000)" old-state="Established" new-state="Idle" event-name="RecvNotify" instance="CUST-A"] BGP peer 10.0.0.2 (External AS 65000) changed state from Established to Idle (event RecvNotify) (instance CUST-A)`
+		result, err := junos.ParseStructured(msg, "er01.gvl01.as14525.net", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, "10.0.0.2", attrs["remote"])
+		assert.Equal(t, "65000", attrs["remote_as"])
+		assert.Equal(t, "CUST-A", attrs["table"])
+		assert.Equal(t, types.IDLE, attrs["fsm_state"])
+		assert.Equal(t, types.ESTABLISHED, attrs["previous_fsm_state"])
+		assert.True(t, result.Down())
+	})
+	t.Run("bgp in extra", func(t *testing.T) {
+		t.Parallel()
+		line := `<28>1 2024-06-01T12:00:00.000Z er01.gvl01.as14525.net rpd 2121 BGP_PEER_STATE_CHANGED [junos@2636.1.1.1.2.29 peer-name="2604:c0c0:3000::13e2+179" peer-as="14525" old-state="OpenConfirm" new-state="Established" event-name="RecvKeepAlive"] BGP peer 2604:c0c0:3000::13e2 (Internal AS 14525) changed state from OpenConfirm to Established (event RecvKeepAlive)`
+		msg, err := syslog.Parse(line)
+		require.NoError(t, err)
+		result, err := junos.Parse(msg.Request(junos.Platform))
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		attrs := result[0].Attrs()
+		assert.Equal(t, "2604:c0c0:3000::13e2", attrs["remote"])
+		assert.Equal(t, "14525", attrs["remote_as"])
+		assert.Equal(t, "master", attrs["table"])
+		assert.True(t, result[0].Up())
+	})
+	t.Run("decoded extra", func(t *testing.T) {
+		t.Parallel()
+		extra := map[string]any{
+			"msgid":           "RPD_ISIS_ADJDOWN",
+			"structured_data": map[string]any{"junos@2636.1.1.1.2.29": map[string]any{"nbr-name": "er02.hnl01.as14525.net", "interface-name": "ae0.3613", "reason": "Aged out"}},
+		}
+		result := junos.ParseAll(&types.Request{Messages: []string{"IS-IS lost adjacency"}, Extra: extra})
+		require.Len(t, result.Logs, 1)
+		assert.Equal(t, "structured_data", result.Outcomes[0].Pattern)
+		attrs := result.Logs[0].Attrs()
+		assert.Equal(t, "er02.hnl01.as14525.net", attrs["remote"])
+		assert.Equal(t, "ae0.3613", attrs["interface"])
+		assert.Equal(t, "Aged out", attrs["reason"])
+		assert.True(t, result.Logs[0].Down())
+	})
+	t.Run("extra of several messages", func(t *testing.T) {
+		t.Parallel()
+		extra := map[string]any{
+			"msgid":           "RPD_ISIS_ADJDOWN",
+			"structured_data": map[string]any{"junos@2636.1.1.1.2.29": map[string]any{"nbr-name": "er02.hnl01.as14525.net", "interface-name": "ae0.3613"}},
+		}
+		result := junos.ParseAll(&types.Request{Messages: []string{"IS-IS lost L2 adjacency to er03.hnl01.as14525.net on ae1.3613", "something else"}, Extra: extra})
+		require.Len(t, result.Logs, 1)
+		assert.Equal(t, "isis", result.Outcomes[0].Pattern)
+		assert.Equal(t, "er03.hnl01.as14525.net", result.Logs[0].Attrs()["remote"])
+		assert.ErrorIs(t, result.Outcomes[1].Err, types.ErrNoMatchingParser)
+	})
+	t.Run("missing peer-name", func(t *testing.T) {
+		t.Parallel()
+		msg := `BGP_PEER_STATE_CHANGED [junos@2636.1.1.1.2.29 new-state="Idle"] BGP peer changed state`
+		_, err := junos.ParseStructured(msg, "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+		var pe *types.ParseError
+		require.ErrorAs(t, err, &pe)
+		assert.Equal(t, []string{"peer-name"}, pe.Missing)
+	})
+	t.Run("other events fall through", func(t *testing.T) {
+		t.Parallel()
+		msg := `RPD_OSPF_NBRUP [junos@2636.1.1.1.2.29 peer-address="10.0.0.2"] OSPF neighbor 10.0.0.2 (realm ospf-v2 ge-0/0/0.0 area 0.0.0.0) state changed from Loading to Full due to LoadDone (event reason: OSPF loadDone)`
+		result := junos.ParseAll(&types.Request{Messages: []string{msg}})
+		assert.NotEqual(t, "structured_data", result.Outcomes[0].Pattern)
+	})
+}
+
+func Test_ParseAll(t *testing.T) {
+	req := &types.Request{Messages: []string{
+		"IS-IS new L2 adjacency to er02.hnl01.as14525.net on ae0.3613",
+		"BGP peer 10.0.0.2 changed state",
+		"something else entirely",
+	}}
+	result := junos.ParseAll(req)
+	require.Len(t, result.Logs, 1)
+	assert.True(t, result.Logs[0].Is(types.ISISLogType))
+	require.Len(t, result.Outcomes, 3)
+	assert.Equal(t, "isis", result.Outcomes[0].Pattern)
+	assert.NoError(t, result.Outcomes[0].Err)
+	assert.Equal(t, "bgp", result.Outcomes[1].Pattern)
+	assert.ErrorIs(t, result.Outcomes[1].Err, types.ErrIncompleteMatch)
+	assert.ErrorIs(t, result.Outcomes[2].Err, types.ErrNoMatchingParser)
+	_, err := junos.Parse(req)
+	assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+}
+
 func Test_Parse(t *testing.T) {
+	t.Run("custom pattern", func(t *testing.T) {
+		t.Parallel()
+		junos.Patterns.Add(types.Pattern{
+			Name:  "test_custom",
+			Match: types.PrefixMatcher("TEST_CUSTOM_ISIS"),
+			Parse: func(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+				return junos.ParseISIS(msg[len("TEST_CUSTOM_ISIS: "):], src, ts, extra)
+			},
+			Priority:    100,
+			StopOnMatch: true,
+		})
+		t.Cleanup(func() { junos.Patterns.Remove("test_custom") })
+		assert.Equal(t, "test_custom", junos.Patterns.List()[0].Name)
+		req := &types.Request{Messages: []string{"TEST_CUSTOM_ISIS: IS-IS new L2 adjacency to er02.hnl01.as14525.net on ae0.3613"}}
+		result, err := junos.Parse(req)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.True(t, result[0].Is(types.ISISLogType))
+	})
 	t.Run("isis", func(t *testing.T) {
 		t.Parallel()
 		req := &types.Request{Messages: []string{"IS-IS new L2 adjacency to er02.hnl01.as14525.net on ae0.3613"}}
@@ -99,6 +627,51 @@ func Test_Parse(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotNil(t, result)
 	})
+	t.Run("notification", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"bgp_read_message:2992: NOTIFICATION received from 10.0.0.1 (External AS 65001): code 6 (Cease) subcode 4 (Administratively Reset)"}}
+		result, err := junos.Parse(req)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.True(t, result[0].Is(types.BGPNotificationLogType))
+		assert.Equal(t, "10.0.0.1", result[0].Attrs()["remote"])
+	})
+	t.Run("ospf", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"RPD_OSPF_NBRUP: OSPF neighbor 10.0.0.2 (realm ospf-v2 ge-0/0/0.0 area 0.0.0.0) state changed from Loading to Full due to LoadDone (event reason: OSPF loadDone)"}}
+		result, err := junos.Parse(req)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.True(t, result[0].Is(types.OSPFLogType))
+	})
+	t.Run("bfd", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"bfdd_trap_shop_state_down: local discriminator: 16, new state: down, interface: ae0.3613, peer addr: 10.0.0.2"}}
+		result, err := junos.Parse(req)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.True(t, result[0].Is(types.BFDLogType))
+	})
+	t.Run("interface", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"SNMP_TRAP_LINK_DOWN: ifIndex 526, ifAdminStatus up(1), ifOperStatus down(2), ifName xe-0/0/1"}}
+		result, err := junos.Parse(req)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.True(t, result[0].Is(types.InterfaceLogType))
+	})
+	t.Run("mpls", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{
+			"RPD_LDP_SESSIONDOWN: LDP session 10.255.0.2 is down, reason: hold time expired",
+			"RPD_MPLS_LSP_DOWN: MPLS LSP er01-to-er02 down on primary(via-hnl01)",
+		}}
+		result, err := junos.Parse(req)
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.True(t, result[0].Is(types.LDPLogType))
+		assert.True(t, result[1].Is(types.RSVPLogType))
+	})
 	t.Run("no match", func(t *testing.T) {
 		t.Parallel()
 		req := &types.Request{Messages: []string{"this has no match"}}
@@ -131,3 +704,46 @@ func Test_Parse(t *testing.T) {
 		assert.Nil(t, result)
 	})
 }
+
+func Test_Explain(t *testing.T) {
+	fixtures := map[string]string{
+		"structured_data":  `BGP_PEER_STATE_CHANGED [junos@2636.1.1.1.2.29 peer-name="10.0.0.2 (External AS 65000)" old-state="Established" new-state="Idle" event-name="RecvNotify"] BGP peer 10.0.0.2 (External AS 65000) changed state from Established to Idle (event RecvNotify)`,
+		"isis":             "IS-IS lost L2 adjacency to er02.hnl01.as14525.net on ae0.3613, reason: Aged out",
+		"bgp":              "BGP peer 2604:c0c0:3000::13e2 (Internal AS 14525) changed state from Established to Idle (event RecvNotify) (instance master)",
+		"ospf":             "OSPF neighbor 10.0.0.2 (realm ospf-v2 ae0.3613 area 0.0.0.10) state changed from Full to Down due to KillNbr (event reason: interface went down)",
+		"ospf_event":       "RPD_OSPF_NBRUP: OSPF neighbor 10.0.0.2 (realm ospf-v2 ge-0/0/0.0 area 0.0.0.0) state changed from Loading to Full due to LoadDone (event reason: OSPF loadDone)",
+		"bfd_event":        "BFDD_STATE_UP_TO_DOWN: BFD Session 10.0.0.2 (IFL 345) state Up -> Down LD/RD(16/17) Up time:1d 02:03 Local diag: CtlExpire Remote diag: None Reason: Detect Timer Expiry.",
+		"bfd":              "BFD Session 10.0.0.2 (IFL 345) state Down -> Up LD/RD(16/17)",
+		"bfd_trap":         "bfdd_trap_shop_state_down: local discriminator: 16, new state: down, interface: ae0.3613, peer addr: 10.0.0.2",
+		"link":             "SNMP_TRAP_LINK_DOWN: ifIndex 526, ifAdminStatus up(1), ifOperStatus down(2), ifName xe-0/0/1",
+		"lacp":             "LACPD_TIMEOUT: xe-0/0/1: lacp current while timer expired current Receive State: CURRENT",
+		"ldp_event":        "RPD_LDP_SESSIONDOWN: LDP session 10.255.0.2:0 is down, reason: received notification from peer",
+		"ldp":              "LDP session 10.255.0.2 (instance master) is up",
+		"lsp_event":        "RPD_MPLS_LSP_DOWN: MPLS LSP er01-to-er02 down on primary(via-hnl01), Reason: session preempted",
+		"lsp":              "MPLS LSP er01-to-er02 up on primary(via-hnl01) Route  10.0.0.2 10.0.1.2",
+		"rsvp_event":       "RPD_RSVP_LSPDOWN: RSVP LSP er01-to-er02 from 10.255.0.1 to 10.255.0.2 is down, reason: PathErr received",
+		"rsvp":             "RSVP LSP er01-to-er02 from 10.255.0.1 to 10.255.0.2 is up",
+		"bgp_notification": "bgp_read_message:2992: NOTIFICATION received from 10.0.0.1 (External AS 65001): code 6 (Cease) subcode 4 (Administratively Reset)",
+		"bgp_prefix_limit": "BGP_PREFIX_THRESH_EXCEEDED: 2604:c0c0:3000::13e2 (External AS 65000): Configured maximum prefix-limit threshold(80) exceeded for inet6-unicast nlri: 81 (instance CUST-A)",
+	}
+	for _, pattern := range junos.Patterns.List() {
+		msg, ok := fixtures[pattern.Name]
+		if !assert.True(t, ok, "no fixture for pattern %s", pattern.Name) {
+			continue
+		}
+		result := junos.Patterns.Explain(&types.Request{Messages: []string{msg}})
+		require.Len(t, result.Outcomes, 1)
+		assert.Equal(t, pattern.Name, result.Outcomes[0].Pattern)
+		assert.NoError(t, result.Outcomes[0].Err, pattern.Name)
+		assert.NotEmpty(t, result.Outcomes[0].Captures, pattern.Name)
+	}
+	t.Run("structured data in extra", func(t *testing.T) {
+		extra := map[string]any{
+			"msgid":           "RPD_ISIS_ADJDOWN",
+			"structured_data": map[string]any{"junos@2636.1.1.1.2.29": map[string]any{"nbr-name": "er02.hnl01.as14525.net", "interface-name": "ae0.3613"}},
+		}
+		result := junos.Patterns.Explain(&types.Request{Messages: []string{"IS-IS lost adjacency"}, Extra: extra})
+		require.Len(t, result.Outcomes, 1)
+		assert.Equal(t, map[string]string{"event": "RPD_ISIS_ADJDOWN", "nbr-name": "er02.hnl01.as14525.net", "interface-name": "ae0.3613"}, result.Outcomes[0].Captures)
+	})
+}
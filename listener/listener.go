@@ -0,0 +1,375 @@
+// Package listener receives syslog over UDP, TCP and TLS, parses each message with parselog and
+// writes the resulting logs to a sink. TCP and TLS streams may use either octet-counting or
+// newline framing, as described in RFC 6587, and may mix them from one frame to the next.
+package listener
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/stellaraf/go-parselog"
+	"github.com/stellaraf/go-parselog/syslog"
+	"github.com/stellaraf/go-parselog/types"
+)
+
+// ErrServerClosed is returned by the Serve methods after the server has been closed.
+var ErrServerClosed = errors.New("listener: server closed")
+
+// ErrFrameTooLarge is returned when a TCP frame exceeds the server's maximum message size.
+var ErrFrameTooLarge = errors.New("listener: frame exceeds maximum message size")
+
+// ErrInvalidFrame is returned when an octet-counted TCP frame has an invalid length.
+var ErrInvalidFrame = errors.New("listener: invalid octet-counted frame")
+
+const (
+	defaultMaxMessageSize int = 64 * 1024
+	maxLengthDigits       int = 10
+)
+
+// Sink receives every log parsed by a server. Emit may be called concurrently.
+type Sink interface {
+	Emit(types.Log) error
+}
+
+// SinkFunc adapts a function to a Sink.
+type SinkFunc func(types.Log) error
+
+func (f SinkFunc) Emit(l types.Log) error {
+	return f(l)
+}
+
+// JSON writes each log to a writer as a line of JSON.
+type JSON struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func NewJSON(w io.Writer) *JSON {
+	return &JSON{enc: json.NewEncoder(w)}
+}
+
+func (j *JSON) Emit(l types.Log) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.enc.Encode(l)
+}
+
+type multiSink []Sink
+
+// Multi returns a sink that emits each log to every one of sinks, returning their errors joined.
+func Multi(sinks ...Sink) Sink {
+	return multiSink(sinks)
+}
+
+func (m multiSink) Emit(l types.Log) error {
+	errs := make([]error, 0)
+	for _, sink := range m {
+		if err := sink.Emit(l); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Server parses syslog messages received by its listeners. The zero value detects each message's
+// platform with parselog.DefaultDetectPolicy and discards the logs; set Sink to keep them.
+type Server struct {
+	// Platform is the platform of every message. When empty, the platform of each message is
+	// detected with DetectPolicy.
+	Platform string
+	// DetectPolicy is used when Platform is empty. The zero value uses parselog.DefaultDetectPolicy.
+	DetectPolicy parselog.DetectPolicy
+	Sink         Sink
+	// ErrorLog receives connection, syslog and parsing errors. Messages that match no pattern
+	// are not logged; use parselog.SetUnmatchedSink to collect them. A nil ErrorLog discards
+	// errors.
+	ErrorLog *log.Logger
+	// MaxMessageSize limits the size of a message, or TCP frame, in bytes. Defaults to 64KiB.
+	MaxMessageSize int
+
+	mu      sync.Mutex
+	closed  bool
+	closers map[io.Closer]struct{}
+	wg      sync.WaitGroup
+}
+
+// ListenAndServeUDP listens on a UDP address and serves it until the server is closed.
+func (s *Server) ListenAndServeUDP(addr string) error {
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return err
+	}
+	return s.ServeUDP(conn)
+}
+
+// ListenAndServeTCP listens on a TCP address and serves it until the server is closed.
+func (s *Server) ListenAndServeTCP(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(l)
+}
+
+// ListenAndServeTLS listens on a TCP address, accepting TLS connections with config, and serves it
+// until the server is closed.
+func (s *Server) ListenAndServeTLS(addr string, config *tls.Config) error {
+	l, err := tls.Listen("tcp", addr, config)
+	if err != nil {
+		return err
+	}
+	return s.Serve(l)
+}
+
+// ServeUDP handles each datagram received on conn as a single message. It always returns a
+// non-nil error, and closes conn when it returns.
+func (s *Server) ServeUDP(conn net.PacketConn) error {
+	if !s.track(conn) {
+		conn.Close()
+		return ErrServerClosed
+	}
+	defer s.untrack(conn)
+	defer conn.Close()
+
+	buf := make([]byte, s.maxMessageSize())
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if n > 0 {
+			s.Handle(string(buf[:n]), addr)
+		}
+		if err != nil {
+			if s.isClosed() {
+				return ErrServerClosed
+			}
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				continue
+			}
+			return err
+		}
+	}
+}
+
+// Serve accepts stream connections, such as TCP or TLS, on l and handles each frame of each
+// connection as a message. It always returns a non-nil error, and closes l when it returns.
+func (s *Server) Serve(l net.Listener) error {
+	if !s.track(l) {
+		l.Close()
+		return ErrServerClosed
+	}
+	defer s.untrack(l)
+	defer l.Close()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if s.isClosed() {
+				return ErrServerClosed
+			}
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				continue
+			}
+			return err
+		}
+		if !s.track(conn) {
+			conn.Close()
+			return ErrServerClosed
+		}
+		go s.serveConn(conn)
+	}
+}
+
+func (s *Server) serveConn(conn net.Conn) {
+	defer s.untrack(conn)
+	defer conn.Close()
+
+	r := bufio.NewReaderSize(conn, s.maxMessageSize())
+	for {
+		frame, err := ReadFrame(r, s.maxMessageSize())
+		if frame != "" {
+			s.Handle(frame, conn.RemoteAddr())
+		}
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !s.isClosed() {
+				s.logf("listener: %s: %s", conn.RemoteAddr(), err)
+			}
+			return
+		}
+	}
+}
+
+// ReadFrame reads the next syslog frame from a stream. A frame beginning with a digit is
+// octet-counted ("<length> <message>"), and any other frame is terminated by a newline. The
+// message is returned without its length or trailing newline. r should be able to buffer at
+// least max bytes.
+func ReadFrame(r *bufio.Reader, max int) (string, error) {
+	for {
+		first, err := r.Peek(1)
+		if err != nil {
+			return "", err
+		}
+		if first[0] >= '1' && first[0] <= '9' {
+			return readOctetCounted(r, max)
+		}
+		line, err := r.ReadSlice('\n')
+		if errors.Is(err, bufio.ErrBufferFull) || len(line) > max {
+			return "", ErrFrameTooLarge
+		}
+		frame := strings.TrimRight(string(line), "\r\n\x00")
+		if frame == "" && err == nil {
+			continue
+		}
+		return frame, err
+	}
+}
+
+func readOctetCounted(r *bufio.Reader, max int) (string, error) {
+	prefix, err := r.ReadSlice(' ')
+	if err != nil && !errors.Is(err, bufio.ErrBufferFull) {
+		if errors.Is(err, io.EOF) {
+			return "", io.ErrUnexpectedEOF
+		}
+		return "", err
+	}
+	digits := strings.TrimSuffix(string(prefix), " ")
+	if err != nil || len(digits) > maxLengthDigits {
+		return "", ErrInvalidFrame
+	}
+	n, err := strconv.Atoi(digits)
+	if err != nil || n <= 0 {
+		return "", ErrInvalidFrame
+	}
+	if n > max {
+		return "", ErrFrameTooLarge
+	}
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		if errors.Is(err, io.EOF) {
+			return "", io.ErrUnexpectedEOF
+		}
+		return "", err
+	}
+	return strings.TrimRight(string(buf), "\r\n\x00"), nil
+}
+
+// Handle parses a single syslog message received from addr and emits its logs to the sink. The
+// address is used as the source when the message has no hostname.
+func (s *Server) Handle(line string, addr net.Addr) {
+	msg, err := syslog.Parse(line)
+	if err != nil {
+		s.logf("listener: %s: %s", addr, err)
+		return
+	}
+	req := msg.Request(s.Platform)
+	if req.Source == "" && addr != nil {
+		req.Source = host(addr)
+	}
+
+	var logs []types.Log
+	if s.Platform != "" {
+		logs, err = parselog.Parse(req)
+	} else {
+		var detection *parselog.Detection
+		detection, err = parselog.Detect(req, s.detectPolicy())
+		if err == nil {
+			logs = detection.Logs
+		}
+	}
+	if err != nil {
+		if !errors.Is(err, types.ErrNoMatchingParser) && !errors.Is(err, types.ErrNoMatchingPlatform) {
+			s.logf("listener: %s: %s", addr, err)
+		}
+		return
+	}
+	if s.Sink == nil {
+		return
+	}
+	for _, l := range logs {
+		if err := s.Sink.Emit(l); err != nil {
+			s.logf("listener: emitting log from %s: %s", addr, err)
+		}
+	}
+}
+
+// Close stops every listener and closes every open connection, then waits for the connections'
+// remaining messages to be handled and for the Serve methods to return.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	errs := make([]error, 0)
+	for closer := range s.closers {
+		if err := closer.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+			errs = append(errs, err)
+		}
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+	return errors.Join(errs...)
+}
+
+// track registers a listener or connection to be closed by Close, and for Close to wait until it
+// is untracked. It's added to the wait group under the same lock Close sets closed with, so Close
+// can't start waiting before a closer it didn't close has been added.
+func (s *Server) track(closer io.Closer) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
+	}
+	if s.closers == nil {
+		s.closers = make(map[io.Closer]struct{})
+	}
+	s.closers[closer] = struct{}{}
+	s.wg.Add(1)
+	return true
+}
+
+func (s *Server) untrack(closer io.Closer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.closers, closer)
+	s.wg.Done()
+}
+
+func (s *Server) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+func (s *Server) maxMessageSize() int {
+	if s.MaxMessageSize <= 0 {
+		return defaultMaxMessageSize
+	}
+	return s.MaxMessageSize
+}
+
+func (s *Server) detectPolicy() parselog.DetectPolicy {
+	if s.DetectPolicy == (parselog.DetectPolicy{}) {
+		return parselog.DefaultDetectPolicy
+	}
+	return s.DetectPolicy
+}
+
+func (s *Server) logf(format string, args ...any) {
+	if s.ErrorLog != nil {
+		s.ErrorLog.Output(2, fmt.Sprintf(format, args...))
+	}
+}
+
+func host(addr net.Addr) string {
+	if h, _, err := net.SplitHostPort(addr.String()); err == nil {
+		return h
+	}
+	return addr.String()
+}
@@ -0,0 +1,220 @@
+package listener_test
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stellaraf/go-parselog/junos"
+	"github.com/stellaraf/go-parselog/listener"
+	"github.com/stellaraf/go-parselog/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	junosLine  = `<28>1 2024-07-13T21:57:59.123Z er01.gvl01.as14525.net rpd 2217 - - BGP peer 10.0.0.2 (External AS 65000) changed state from Established to Idle (event RecvNotify) (instance master)`
+	aristaLine = `<187>Jul 13 21:57:59 leaf0401 Bgp: %BGP-3-NOTIFICATION: received from neighbor 10.0.0.1 (VRF default AS 65000) 4/0 (Hold Timer Expired) 0 bytes`
+)
+
+// collect returns a sink that sends every log to a channel.
+func collect() (listener.Sink, chan types.Log) {
+	logs := make(chan types.Log, 16)
+	return listener.SinkFunc(func(l types.Log) error {
+		logs <- l
+		return nil
+	}), logs
+}
+
+func receive(t *testing.T, logs chan types.Log) types.Log {
+	t.Helper()
+	select {
+	case l := <-logs:
+		return l
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for a log")
+		return nil
+	}
+}
+
+func serve(t *testing.T, server *listener.Server, l net.Listener) {
+	t.Helper()
+	done := make(chan error, 1)
+	go func() { done <- server.Serve(l) }()
+	t.Cleanup(func() {
+		require.NoError(t, server.Close())
+		assert.ErrorIs(t, <-done, listener.ErrServerClosed)
+	})
+}
+
+func certificate(t *testing.T) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func Test_ReadFrame(t *testing.T) {
+	t.Run("mixed framing", func(t *testing.T) {
+		t.Parallel()
+		stream := "10 <1>1 - - -\n\n<2>2 - -\r\n7 <3>3 -\n<4>4 unterminated"
+		r := bufio.NewReader(strings.NewReader(stream))
+		frames := make([]string, 0)
+		for {
+			frame, err := listener.ReadFrame(r, 1024)
+			if frame != "" {
+				frames = append(frames, frame)
+			}
+			if err != nil {
+				assert.ErrorIs(t, err, io.EOF)
+				break
+			}
+		}
+		assert.Equal(t, []string{"<1>1 - - -", "<2>2 - -", "<3>3 -", "<4>4 unterminated"}, frames)
+	})
+	t.Run("too large", func(t *testing.T) {
+		t.Parallel()
+		_, err := listener.ReadFrame(bufio.NewReaderSize(strings.NewReader("2048 <1>"), 16), 16)
+		assert.ErrorIs(t, err, listener.ErrFrameTooLarge)
+		_, err = listener.ReadFrame(bufio.NewReaderSize(strings.NewReader(strings.Repeat("x", 64)+"\n"), 16), 16)
+		assert.ErrorIs(t, err, listener.ErrFrameTooLarge)
+	})
+	t.Run("invalid length", func(t *testing.T) {
+		t.Parallel()
+		_, err := listener.ReadFrame(bufio.NewReader(strings.NewReader("12x <1>\n")), 1024)
+		assert.ErrorIs(t, err, listener.ErrInvalidFrame)
+	})
+	t.Run("truncated", func(t *testing.T) {
+		t.Parallel()
+		_, err := listener.ReadFrame(bufio.NewReader(strings.NewReader("20 <1>1 short")), 1024)
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+}
+
+func Test_Server(t *testing.T) {
+	t.Run("udp", func(t *testing.T) {
+		t.Parallel()
+		sink, logs := collect()
+		server := &listener.Server{Platform: junos.Platform, Sink: sink}
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		done := make(chan error, 1)
+		go func() { done <- server.ServeUDP(conn) }()
+		t.Cleanup(func() {
+			require.NoError(t, server.Close())
+			assert.ErrorIs(t, <-done, listener.ErrServerClosed)
+		})
+
+		client, err := net.Dial("udp", conn.LocalAddr().String())
+		require.NoError(t, err)
+		defer client.Close()
+		_, err = client.Write([]byte(junosLine))
+		require.NoError(t, err)
+
+		l := receive(t, logs)
+		assert.True(t, l.Is(types.BGPLogType))
+		assert.Equal(t, "er01.gvl01.as14525.net", l.Attrs()["local"])
+		assert.Equal(t, "10.0.0.2", l.Attrs()["remote"])
+	})
+	t.Run("tcp", func(t *testing.T) {
+		t.Parallel()
+		sink, logs := collect()
+		server := &listener.Server{Platform: junos.Platform, Sink: sink}
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		serve(t, server, l)
+
+		client, err := net.Dial("tcp", l.Addr().String())
+		require.NoError(t, err)
+		defer client.Close()
+		_, err = fmt.Fprintf(client, "%d %s%s\n", len(junosLine), junosLine, junosLine)
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			assert.True(t, receive(t, logs).Is(types.BGPLogType))
+		}
+	})
+	t.Run("tls", func(t *testing.T) {
+		t.Parallel()
+		sink, logs := collect()
+		server := &listener.Server{Sink: sink}
+		cert := certificate(t)
+		l, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{cert}})
+		require.NoError(t, err)
+		serve(t, server, l)
+
+		roots := x509.NewCertPool()
+		parsed, err := x509.ParseCertificate(cert.Certificate[0])
+		require.NoError(t, err)
+		roots.AddCert(parsed)
+		client, err := tls.Dial("tcp", l.Addr().String(), &tls.Config{RootCAs: roots})
+		require.NoError(t, err)
+		defer client.Close()
+		_, err = fmt.Fprintf(client, "%d %s", len(aristaLine), aristaLine)
+		require.NoError(t, err)
+
+		received := receive(t, logs)
+		assert.True(t, received.Is(types.BGPNotificationLogType))
+		assert.Equal(t, "leaf0401", received.Attrs()["local"])
+	})
+	t.Run("source from address", func(t *testing.T) {
+		t.Parallel()
+		sink, logs := collect()
+		server := &listener.Server{Platform: junos.Platform, Sink: sink}
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		serve(t, server, l)
+
+		client, err := net.Dial("tcp", l.Addr().String())
+		require.NoError(t, err)
+		defer client.Close()
+		_, err = fmt.Fprintln(client, strings.Replace(junosLine, "er01.gvl01.as14525.net", "-", 1))
+		require.NoError(t, err)
+		assert.Equal(t, "127.0.0.1", receive(t, logs).Attrs()["local"])
+	})
+	t.Run("closed", func(t *testing.T) {
+		t.Parallel()
+		server := &listener.Server{}
+		require.NoError(t, server.Close())
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		assert.ErrorIs(t, server.Serve(l), listener.ErrServerClosed)
+	})
+}
+
+func Test_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	l, err := junos.ParseBGP("BGP peer 10.0.0.2 (External AS 65000) changed state from Established to Idle (event RecvNotify) (instance master)", "er01", time.Now(), nil)
+	require.NoError(t, err)
+	sink := listener.Multi(listener.NewJSON(&buf), listener.NewJSON(&buf))
+	require.NoError(t, sink.Emit(l))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &decoded))
+	assert.Equal(t, "10.0.0.2", decoded["remote"])
+}
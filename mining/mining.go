@@ -0,0 +1,288 @@
+// Package mining clusters messages into templates using a Drain-style fixed-depth prefix tree, to
+// find the messages that are most worth writing patterns for. A Miner is an UnmatchedSink, so it
+// can be fed directly from the parse path with parselog.SetUnmatchedSink.
+package mining
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/stellaraf/go-parselog/patternfile"
+	"github.com/stellaraf/go-parselog/types"
+)
+
+// Wildcard replaces the variable parts of a template.
+const Wildcard string = "<*>"
+
+const (
+	defaultDepth       int     = 4
+	defaultSimilarity  float64 = 0.5
+	defaultMaxChildren int     = 100
+)
+
+// Config tunes the clustering. Zero values are replaced with defaults.
+type Config struct {
+	// Depth is the depth of the prefix tree, including the root and token count levels, so
+	// Depth-2 leading tokens are used to find candidate clusters. Defaults to 4.
+	Depth int
+	// Similarity is the share of a message's tokens that must equal a cluster's template for the
+	// message to join it. Defaults to 0.5.
+	Similarity float64
+	// MaxChildren limits the children of each tree node; further tokens share a wildcard child.
+	// Defaults to 100.
+	MaxChildren int
+}
+
+// Cluster is a group of messages sharing a template.
+type Cluster struct {
+	ID       int      `json:"id"`
+	Platform string   `json:"platform"`
+	Tokens   []string `json:"tokens"`
+	Count    int      `json:"count"`
+	// Example is the first message added to the cluster.
+	Example string `json:"example"`
+}
+
+// Template returns the cluster's template, with variable tokens replaced by Wildcard.
+func (c Cluster) Template() string {
+	return strings.Join(c.Tokens, " ")
+}
+
+// Pattern returns an anchored regular expression matching the cluster's messages, with a named
+// group for each wildcard.
+func (c Cluster) Pattern() string {
+	var b strings.Builder
+	b.WriteString("^")
+	group := 0
+	for i, token := range c.Tokens {
+		if i > 0 {
+			b.WriteString(`\s+`)
+		}
+		parts := strings.Split(token, Wildcard)
+		for j, part := range parts {
+			if j > 0 {
+				group++
+				if j == len(parts)-1 && part == "" {
+					fmt.Fprintf(&b, `(?P<var%d>\S+)`, group)
+				} else {
+					fmt.Fprintf(&b, `(?P<var%d>\S+?)`, group)
+				}
+			}
+			b.WriteString(regexp.QuoteMeta(part))
+		}
+	}
+	b.WriteString("$")
+	return b.String()
+}
+
+type node struct {
+	children map[string]*node
+	clusters []*Cluster
+}
+
+func newNode() *node {
+	return &node{children: make(map[string]*node)}
+}
+
+// Miner clusters messages. It is safe for concurrent use.
+type Miner struct {
+	mu       sync.Mutex
+	config   Config
+	roots    map[string]*node
+	clusters []*Cluster
+}
+
+func New(config Config) *Miner {
+	if config.Depth < 3 {
+		config.Depth = defaultDepth
+	}
+	if config.Similarity <= 0 {
+		config.Similarity = defaultSimilarity
+	}
+	if config.MaxChildren <= 0 {
+		config.MaxChildren = defaultMaxChildren
+	}
+	return &Miner{config: config, roots: make(map[string]*node)}
+}
+
+// Capture adds an unmatched message, clustered with other messages of the same platform.
+func (m *Miner) Capture(u types.Unmatched) {
+	m.AddMessage(u.Platform, u.Message)
+}
+
+// AddMessage adds a message of a platform and returns a copy of the cluster it joined.
+func (m *Miner) AddMessage(platform, msg string) Cluster {
+	tokens := strings.Fields(msg)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	leaf := m.leaf(platform, tokens)
+	if cluster := m.match(leaf, tokens); cluster != nil {
+		cluster.Count++
+		for i, token := range tokens {
+			cluster.Tokens[i] = merge(cluster.Tokens[i], token)
+		}
+		return copyCluster(cluster)
+	}
+	cluster := &Cluster{
+		ID:       len(m.clusters) + 1,
+		Platform: platform,
+		Tokens:   append([]string{}, tokens...),
+		Count:    1,
+		Example:  msg,
+	}
+	leaf.clusters = append(leaf.clusters, cluster)
+	m.clusters = append(m.clusters, cluster)
+	return copyCluster(cluster)
+}
+
+// leaf walks, and grows, the prefix tree of a platform to the leaf holding the candidate clusters
+// for a message: first by token count, then by the leading tokens.
+func (m *Miner) leaf(platform string, tokens []string) *node {
+	root, ok := m.roots[platform]
+	if !ok {
+		root = newNode()
+		m.roots[platform] = root
+	}
+	current := child(root, fmt.Sprint(len(tokens)), m.config.MaxChildren)
+	for i := 0; i < m.config.Depth-2 && i < len(tokens); i++ {
+		key := tokens[i]
+		if hasDigit(key) {
+			key = Wildcard
+		}
+		current = child(current, key, m.config.MaxChildren)
+	}
+	return current
+}
+
+func child(parent *node, key string, maxChildren int) *node {
+	if next, ok := parent.children[key]; ok {
+		return next
+	}
+	if len(parent.children) >= maxChildren-1 {
+		key = Wildcard
+		if next, ok := parent.children[key]; ok {
+			return next
+		}
+	}
+	next := newNode()
+	parent.children[key] = next
+	return next
+}
+
+// match returns the most similar cluster of a leaf, if it's similar enough to join.
+func (m *Miner) match(leaf *node, tokens []string) *Cluster {
+	var best *Cluster
+	bestScore := -1.0
+	for _, cluster := range leaf.clusters {
+		score := similarity(cluster.Tokens, tokens)
+		if score > bestScore {
+			best, bestScore = cluster, score
+		}
+	}
+	if best == nil || bestScore < m.config.Similarity {
+		return nil
+	}
+	return best
+}
+
+// similarity is the share of tokens equal to the template's. Wildcard tokens are not counted as
+// equal, so templates don't become more attractive as they are generalized.
+func similarity(template, tokens []string) float64 {
+	if len(tokens) == 0 {
+		return 1
+	}
+	same := 0
+	for i, token := range tokens {
+		if template[i] == token {
+			same++
+		}
+	}
+	return float64(same) / float64(len(tokens))
+}
+
+// merge generalizes a template token to also match token, keeping the punctuation they share on
+// either side of the variable part, e.g. "65000)" and "65001)" merge into "<*>)".
+func merge(template, token string) string {
+	if template == token {
+		return template
+	}
+	if strings.Contains(template, Wildcard) {
+		prefix, suffix, _ := strings.Cut(template, Wildcard)
+		if strings.HasPrefix(token, prefix) && strings.HasSuffix(token, suffix) && len(token) >= len(prefix)+len(suffix) {
+			return template
+		}
+	}
+	prefix := commonPunct(template, token, false)
+	suffix := commonPunct(template[len(prefix):], token[len(prefix):], true)
+	return prefix + Wildcard + suffix
+}
+
+// commonPunct returns the punctuation shared at the start, or end, of two tokens.
+func commonPunct(a, b string, fromEnd bool) string {
+	n := 0
+	for n < len(a) && n < len(b) {
+		ia, ib := n, n
+		if fromEnd {
+			ia, ib = len(a)-1-n, len(b)-1-n
+		}
+		if a[ia] != b[ib] || isWord(rune(a[ia])) || a[ia] == '<' || a[ia] == '>' {
+			break
+		}
+		n++
+	}
+	if fromEnd {
+		return a[len(a)-n:]
+	}
+	return a[:n]
+}
+
+func isWord(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func hasDigit(s string) bool {
+	return strings.IndexFunc(s, unicode.IsDigit) >= 0
+}
+
+func copyCluster(c *Cluster) Cluster {
+	copied := *c
+	copied.Tokens = append([]string{}, c.Tokens...)
+	return copied
+}
+
+// Clusters returns a copy of every cluster, most common first.
+func (m *Miner) Clusters() []Cluster {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clusters := make([]Cluster, 0, len(m.clusters))
+	for _, cluster := range m.clusters {
+		clusters = append(clusters, copyCluster(cluster))
+	}
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return clusters[i].Count > clusters[j].Count
+	})
+	return clusters
+}
+
+// Skeleton returns a pattern definition for the platform's clusters seen at least minCount
+// times, most common first. Each pattern's match captures every wildcard as var1, var2 etc. The
+// definition is a starting point: the log type, field mappings and states must be filled in
+// before it can be compiled.
+func (m *Miner) Skeleton(platform string, minCount int) *patternfile.Definition {
+	def := &patternfile.Definition{Platform: platform, Patterns: make([]patternfile.PatternDefinition, 0)}
+	for _, cluster := range m.Clusters() {
+		if cluster.Platform != platform || cluster.Count < minCount {
+			continue
+		}
+		def.Patterns = append(def.Patterns, patternfile.PatternDefinition{
+			Name:  fmt.Sprintf("template_%d", cluster.ID),
+			Match: cluster.Pattern(),
+		})
+	}
+	return def
+}
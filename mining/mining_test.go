@@ -0,0 +1,97 @@
+package mining_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stellaraf/go-parselog/mining"
+	"github.com/stellaraf/go-parselog/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var bgpMessages = []string{
+	"BGP peer 10.0.0.1 (External AS 65000) changed state from Established to Idle",
+	"BGP peer 10.0.0.2 (External AS 65001) changed state from OpenConfirm to Established",
+	"BGP peer 2604:c0c0:3000::13e2 (External AS 14525) changed state from Active to Connect",
+}
+
+func Test_Miner(t *testing.T) {
+	t.Run("template", func(t *testing.T) {
+		t.Parallel()
+		m := mining.New(mining.Config{})
+		for _, msg := range bgpMessages {
+			m.AddMessage("junos", msg)
+		}
+		clusters := m.Clusters()
+		require.Len(t, clusters, 1)
+		assert.Equal(t, "BGP peer <*> (External AS <*>) changed state from <*> to <*>", clusters[0].Template())
+		assert.Equal(t, 3, clusters[0].Count)
+		assert.Equal(t, bgpMessages[0], clusters[0].Example)
+	})
+	t.Run("separate clusters", func(t *testing.T) {
+		t.Parallel()
+		m := mining.New(mining.Config{})
+		for _, msg := range bgpMessages {
+			m.AddMessage("junos", msg)
+		}
+		m.AddMessage("junos", "LICENSE_EXPIRED: feature bgp has expired")
+		m.AddMessage("junos", "LICENSE_EXPIRED: feature isis has expired")
+		clusters := m.Clusters()
+		require.Len(t, clusters, 2)
+		assert.Equal(t, 3, clusters[0].Count)
+		assert.Equal(t, "LICENSE_EXPIRED: feature <*> has expired", clusters[1].Template())
+	})
+	t.Run("platforms are separate", func(t *testing.T) {
+		t.Parallel()
+		m := mining.New(mining.Config{})
+		m.AddMessage("junos", bgpMessages[0])
+		m.AddMessage("arista_eos", bgpMessages[1])
+		assert.Len(t, m.Clusters(), 2)
+	})
+	t.Run("unmatched sink", func(t *testing.T) {
+		t.Parallel()
+		m := mining.New(mining.Config{})
+		var sink types.UnmatchedSink = m
+		for _, msg := range bgpMessages {
+			sink.Capture(types.Unmatched{Platform: "junos", Source: "er01", Message: msg})
+		}
+		clusters := m.Clusters()
+		require.Len(t, clusters, 1)
+		assert.Equal(t, "junos", clusters[0].Platform)
+	})
+	t.Run("pattern", func(t *testing.T) {
+		t.Parallel()
+		m := mining.New(mining.Config{})
+		var cluster mining.Cluster
+		for _, msg := range bgpMessages {
+			cluster = m.AddMessage("junos", msg)
+		}
+		re := regexp.MustCompile(cluster.Pattern())
+		for _, msg := range bgpMessages {
+			assert.True(t, re.MatchString(msg), msg)
+		}
+		matches := re.FindStringSubmatch(bgpMessages[1])
+		assert.Equal(t, "10.0.0.2", matches[re.SubexpIndex("var1")])
+		assert.Equal(t, "65001", matches[re.SubexpIndex("var2")])
+		assert.Equal(t, "Established", matches[re.SubexpIndex("var4")])
+	})
+	t.Run("skeleton", func(t *testing.T) {
+		t.Parallel()
+		m := mining.New(mining.Config{})
+		for _, msg := range bgpMessages {
+			m.AddMessage("junos", msg)
+		}
+		m.AddMessage("junos", "LICENSE_EXPIRED: feature bgp has expired")
+		m.AddMessage("arista_eos", "%BGP-5-NEW: something")
+		def := m.Skeleton("junos", 2)
+		assert.Equal(t, "junos", def.Platform)
+		require.Len(t, def.Patterns, 1)
+		assert.Equal(t, "template_1", def.Patterns[0].Name)
+		b, err := yaml.Marshal(def)
+		require.NoError(t, err)
+		assert.Contains(t, string(b), "template_1")
+		assert.NotContains(t, string(b), "fields")
+	})
+}
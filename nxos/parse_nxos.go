@@ -0,0 +1,36 @@
+package nxos
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/stellaraf/go-parselog/types"
+)
+
+var patternISIS = regexp.MustCompile(`%ISIS-5-ADJCHANGE:\s+isis-\S+ \[\d+\]\s+(?:LAN|P2P) adj L[12] (?P<remote>\S+) over (?P<iface>\S+) - (?P<state>[A-Za-z]+)(?: \((?P<reason>[^\)]+)\))?.*$`)
+var patternBGP = regexp.MustCompile(`%BGP-5-ADJCHANGE:\s+bgp-\S+ \[\d+\] \((?P<table>[^\)]+)\) neighbor (?P<remote>\S+) (?P<state>Up|Down)(?: - (?P<reason>.+))?$`)
+
+// Platform is the name this package's parser is registered under.
+const Platform string = "nxos"
+
+// Patterns are evaluated in order against each message, and may be extended or overridden.
+var Patterns = types.NewPatterns(
+	types.Pattern{Name: "isis", Match: types.RegexpMatcher(regexp.MustCompile(`%ISIS-5-ADJCHANGE`)), Parse: ParseISIS, Regexp: patternISIS, StopOnMatch: true},
+	types.Pattern{Name: "bgp", Match: types.RegexpMatcher(regexp.MustCompile(`%BGP-5-ADJCHANGE`)), Parse: ParseBGP, Regexp: patternBGP, StopOnMatch: true},
+)
+
+func ParseISIS(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	return types.ParseISISAdjacency(patternISIS, msg, src, ts, extra)
+}
+
+func ParseBGP(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	return types.ParseBGPAdjacency(patternBGP, "", msg, src, ts, extra)
+}
+
+func init() {
+	types.RegisterPatterns(Platform, Patterns)
+}
+
+func Parse(req *types.Request) ([]types.Log, error) {
+	return Patterns.Parse(req)
+}
@@ -0,0 +1,146 @@
+package nxos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellaraf/go-parselog/nxos"
+	"github.com/stellaraf/go-parselog/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseISIS(t *testing.T) {
+	t.Run("up", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		msg := "%ISIS-5-ADJCHANGE:  isis-core [5432]  P2P adj L2 er02.hnl01 over Ethernet1/49 - UP on MT-0"
+		result, err := nxos.ParseISIS(msg, "dc01.pdx01", now, nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.UP, attrs["state"])
+		assert.Equal(t, "er02.hnl01", attrs["remote"])
+		assert.Equal(t, "Ethernet1/49", attrs["interface"])
+		assert.Empty(t, attrs["reason"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Down())
+		assert.True(t, result.Up())
+	})
+	t.Run("down", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		msg := "%ISIS-5-ADJCHANGE:  isis-core [5432]  LAN adj L2 er02.hnl01 over Ethernet1/49 - DOWN (Hold timer expired) on MT-0"
+		result, err := nxos.ParseISIS(msg, "dc01.pdx01", now, nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.DOWN, attrs["state"])
+		assert.Equal(t, "er02.hnl01", attrs["remote"])
+		assert.Equal(t, "Ethernet1/49", attrs["interface"])
+		assert.Equal(t, "Hold timer expired", attrs["reason"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Up())
+		assert.True(t, result.Down())
+	})
+	t.Run("init", func(t *testing.T) {
+		t.Parallel()
+		msg := "%ISIS-5-ADJCHANGE:  isis-core [5432]  P2P adj L2 er02.hnl01 over Ethernet1/49 - INIT (New) on MT-0"
+		result, err := nxos.ParseISIS(msg, "dc01.pdx01", time.Now(), nil)
+		require.NoError(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := nxos.ParseISIS("%ISIS-5-ADJCHANGE:  isis-core [5432]  P2P adj L2 er02.hnl01", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_ParseBGP(t *testing.T) {
+	t.Run("up", func(t *testing.T) {
+		t.Parallel()
+		msg := "%BGP-5-ADJCHANGE:  bgp-65000 [12345] (default) neighbor 10.0.0.1 Up"
+		result, err := nxos.ParseBGP(msg, "dc01.pdx01", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.UP, attrs["state"])
+		assert.Equal(t, "10.0.0.1", attrs["remote"])
+		assert.Equal(t, "default", attrs["table"])
+		assert.Empty(t, attrs["reason"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Down())
+		assert.True(t, result.Up())
+	})
+	t.Run("down", func(t *testing.T) {
+		t.Parallel()
+		msg := "%BGP-5-ADJCHANGE:  bgp-65000 [12345] (CUST-A) neighbor 2604:c0c0:3000::13e2 Down - sent:  other configuration change"
+		result, err := nxos.ParseBGP(msg, "dc01.pdx01", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.DOWN, attrs["state"])
+		assert.Equal(t, "2604:c0c0:3000::13e2", attrs["remote"])
+		assert.Equal(t, "CUST-A", attrs["table"])
+		assert.Equal(t, "sent:  other configuration change", attrs["reason"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Up())
+		assert.True(t, result.Down())
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := nxos.ParseBGP("%BGP-5-ADJCHANGE:  bgp-65000 [12345] neighbor 10.0.0.1", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_Parse(t *testing.T) {
+	t.Run("isis", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"%ISIS-5-ADJCHANGE:  isis-core [5432]  P2P adj L2 er02.hnl01 over Ethernet1/49 - UP on MT-0"}}
+		result, err := nxos.Parse(req)
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+	t.Run("bgp", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"%BGP-5-ADJCHANGE:  bgp-65000 [12345] (default) neighbor 10.0.0.1 Up"}}
+		result, err := nxos.Parse(req)
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+	t.Run("no match", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"this has no match"}}
+		result, err := nxos.Parse(req)
+		assert.ErrorIs(t, err, types.ErrNoMatchingParser)
+		assert.Nil(t, result)
+	})
+	t.Run("with invalid", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{
+			Messages: []string{
+				"%BGP-5-ADJCHANGE:  bgp-65000 [12345] (default) neighbor 10.0.0.1 Up",
+				"%BGP-5-ADJCHANGE:  bgp-65000 invalid",
+			},
+		}
+		result, err := nxos.Parse(req)
+		require.ErrorIs(t, err, types.ErrIncompleteMatch)
+		assert.Nil(t, result)
+	})
+}
+
+func Test_Explain(t *testing.T) {
+	fixtures := map[string]string{
+		"isis": "%ISIS-5-ADJCHANGE:  isis-core [5432]  LAN adj L2 er02.hnl01 over Ethernet1/49 - DOWN (Hold timer expired) on MT-0",
+		"bgp":  "%BGP-5-ADJCHANGE:  bgp-65000 [12345] (default) neighbor 10.0.0.1 Up",
+	}
+	for _, pattern := range nxos.Patterns.List() {
+		msg, ok := fixtures[pattern.Name]
+		if !assert.True(t, ok, "no fixture for pattern %s", pattern.Name) {
+			continue
+		}
+		result := nxos.Patterns.Explain(&types.Request{Messages: []string{msg}})
+		require.Len(t, result.Outcomes, 1)
+		assert.Equal(t, pattern.Name, result.Outcomes[0].Pattern)
+		assert.NoError(t, result.Outcomes[0].Err, pattern.Name)
+		assert.NotEmpty(t, result.Outcomes[0].Captures, pattern.Name)
+	}
+}
@@ -1,41 +1,130 @@
 package parselog
 
 import (
-	"github.com/stellaraf/go-parselog/arista"
-	"github.com/stellaraf/go-parselog/junos"
+	"errors"
+
+	// Built-in platforms register their parsers on import.
+	_ "github.com/stellaraf/go-parselog/arista"
+	_ "github.com/stellaraf/go-parselog/bird"
+	_ "github.com/stellaraf/go-parselog/frr"
+	_ "github.com/stellaraf/go-parselog/huawei"
+	_ "github.com/stellaraf/go-parselog/iosxe"
+	_ "github.com/stellaraf/go-parselog/iosxr"
+	_ "github.com/stellaraf/go-parselog/junos"
+	_ "github.com/stellaraf/go-parselog/nxos"
+	_ "github.com/stellaraf/go-parselog/sros"
 	"github.com/stellaraf/go-parselog/types"
 )
 
 type (
-	Request = types.Request
-	BGPLog  = types.BGPLog
-	ISISLog = types.ISISLog
-	Log     = types.Log
-	State   = types.State
-	LogType = types.LogType
+	Request            = types.Request
+	BGPLog             = types.BGPLog
+	ISISLog            = types.ISISLog
+	OSPFLog            = types.OSPFLog
+	BFDLog             = types.BFDLog
+	InterfaceLog       = types.InterfaceLog
+	LDPLog             = types.LDPLog
+	RSVPLog            = types.RSVPLog
+	BGPNotificationLog = types.BGPNotificationLog
+	BGPPrefixLimitLog  = types.BGPPrefixLimitLog
+	Log                = types.Log
+	Result             = types.Result
+	Outcome            = types.Outcome
+	State              = types.State
+	LogType            = types.LogType
 )
 
 var (
-	ErrNoMatchingParser   = types.ErrNoMatchingParser
-	ErrIncompleteMatch    = types.ErrIncompleteMatch
-	ErrNoMatchingPlatform = types.ErrNoMatchingPlatform
-	ISIS                  = types.ISIS
-	BGP                   = types.BGP
-	UP                    = types.UP
-	DOWN                  = types.DOWN
-	ISISLogType           = &types.ISISLog{Base: types.Base{Type: ISIS}}
-	BGPLogType            = &types.BGPLog{Base: types.Base{Type: BGP}}
+	ErrNoMatchingParser    = types.ErrNoMatchingParser
+	ErrIncompleteMatch     = types.ErrIncompleteMatch
+	ErrNoMatchingPlatform  = types.ErrNoMatchingPlatform
+	ErrAmbiguousPlatform   = types.ErrAmbiguousPlatform
+	ISIS                   = types.ISIS
+	BGP                    = types.BGP
+	OSPF                   = types.OSPF
+	BFD                    = types.BFD
+	Interface              = types.Interface
+	LDP                    = types.LDP
+	RSVP                   = types.RSVP
+	BGPNotification        = types.BGPNotification
+	BGPPrefixLimit         = types.BGPPrefixLimit
+	UP                     = types.UP
+	DOWN                   = types.DOWN
+	ISISLogType            = &types.ISISLog{Base: types.Base{Type: ISIS}}
+	BGPLogType             = &types.BGPLog{Base: types.Base{Type: BGP}}
+	OSPFLogType            = &types.OSPFLog{Base: types.Base{Type: OSPF}}
+	BFDLogType             = &types.BFDLog{Base: types.Base{Type: BFD}}
+	InterfaceLogType       = &types.InterfaceLog{Base: types.Base{Type: Interface}}
+	LDPLogType             = &types.LDPLog{Base: types.Base{Type: LDP}}
+	RSVPLogType            = &types.RSVPLog{Base: types.Base{Type: RSVP}}
+	BGPNotificationLogType = &types.BGPNotificationLog{Base: types.Base{Type: BGPNotification}}
+	BGPPrefixLimitLogType  = &types.BGPPrefixLimitLog{Base: types.Base{Type: BGPPrefixLimit}}
 )
 
-var parseMap = map[string]types.Parser{
-	"junos":      junos.Parse,
-	"arista_eos": arista.Parse,
+// Register makes a parser available under the given platform name, replacing any parser already
+// registered under that name. It is safe for concurrent use.
+func Register(platform string, parser types.Parser) {
+	types.Register(platform, parser)
+}
+
+// RegisterAlias makes a platform available under an additional name, e.g. eos for arista_eos.
+func RegisterAlias(alias, platform string) {
+	types.RegisterAlias(alias, platform)
+}
+
+// Unregister removes a platform and its aliases, or a single alias, from the registry.
+func Unregister(platform string) {
+	types.Unregister(platform)
+}
+
+// Platforms returns the sorted names of all registered platforms, excluding aliases.
+func Platforms() []string {
+	return types.Platforms()
+}
+
+// ParseAll parses every message of a request it can. Unlike Parse, a message that can't be parsed
+// doesn't discard the logs of the others; its error is recorded in the result's outcomes instead.
+func ParseAll(request *Request) (*Result, error) {
+	var result *Result
+	if patterns, ok := types.LookupPatterns(request.Platform); ok {
+		result = patterns.ParseAll(request)
+	} else if parser, ok := types.Lookup(request.Platform); ok {
+		result = types.ParseEach(parser, request)
+	} else {
+		return nil, types.ErrNoMatchingPlatform
+	}
+	captureUnmatched(request, result)
+	return result, nil
+}
+
+// Explain is ParseAll, with the named groups each pattern captured added to the outcomes, for
+// finding out why a message was or wasn't parsed. Platforms registered without patterns have no
+// captures. Unmatched messages are not sent to the unmatched sink.
+func Explain(request *Request) (*Result, error) {
+	if patterns, ok := types.LookupPatterns(request.Platform); ok {
+		return patterns.Explain(request), nil
+	}
+	if parser, ok := types.Lookup(request.Platform); ok {
+		return types.ParseEach(parser, request), nil
+	}
+	return nil, types.ErrNoMatchingPlatform
 }
 
 func Parse(request *Request) ([]Log, error) {
-	parser, ok := parseMap[request.Platform]
+	if patterns, ok := types.LookupPatterns(request.Platform); ok {
+		result := patterns.ParseAll(request)
+		captureUnmatched(request, result)
+		return result.Unwrap()
+	}
+	parser, ok := types.Lookup(request.Platform)
 	if !ok {
 		return nil, types.ErrNoMatchingPlatform
 	}
-	return parser(request)
+	logs, err := parser(request)
+	// Parsers without patterns don't report which messages they couldn't match, so every
+	// message is captured only when none of them matched.
+	if errors.Is(err, types.ErrNoMatchingParser) {
+		captureAll(request)
+	}
+	return logs, err
 }
@@ -120,6 +120,133 @@ func Test_Parse(t *testing.T) {
 			assert.True(t, log.Is(parselog.ISISLogType))
 		}
 	})
+	t.Run("iosxr base", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		req := &types.Request{
+			Messages:  []string{"%ROUTING-BGP-5-ADJCHANGE : neighbor 10.0.0.1 Up (VRF: default) (AS: 65000)"},
+			Timestamp: now,
+			Platform:  "iosxr",
+			Source:    "cr01.sea01.as14525.net",
+		}
+		result, err := parselog.Parse(req)
+		require.NoError(t, err)
+		log, ok := result[0].(*types.BGPLog)
+		require.True(t, ok)
+		assert.Equal(t, req.Timestamp, log.Timestamp)
+		assert.Equal(t, "10.0.0.1", log.Remote)
+		assert.Equal(t, "65000", log.RemoteAS)
+		assert.Equal(t, "default", log.Table)
+		assert.True(t, log.Up())
+		assert.True(t, result[0].Is(parselog.BGPLogType))
+	})
+	t.Run("iosxe base", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{
+			Messages:  []string{"%BGP-5-ADJCHANGE: neighbor 10.0.0.1 vpn vrf CUST-A Up"},
+			Timestamp: time.Now(),
+			Platform:  "iosxe",
+			Source:    "ce01.pdx01",
+		}
+		result, err := parselog.Parse(req)
+		require.NoError(t, err)
+		log, ok := result[0].(*types.BGPLog)
+		require.True(t, ok)
+		assert.Equal(t, req.Timestamp, log.Timestamp)
+		assert.Equal(t, "10.0.0.1", log.Remote)
+		assert.Equal(t, "CUST-A", log.Table)
+		assert.True(t, log.Up())
+		assert.True(t, result[0].Is(parselog.BGPLogType))
+	})
+	t.Run("nxos base", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{
+			Messages:  []string{"%ISIS-5-ADJCHANGE:  isis-core [5432]  P2P adj L2 er02.hnl01 over Ethernet1/49 - DOWN (Hold timer expired) on MT-0"},
+			Timestamp: time.Now(),
+			Platform:  "nxos",
+			Source:    "dc01.pdx01",
+		}
+		result, err := parselog.Parse(req)
+		require.NoError(t, err)
+		log, ok := result[0].(*types.ISISLog)
+		require.True(t, ok)
+		assert.Equal(t, req.Timestamp, log.Timestamp)
+		assert.Equal(t, "er02.hnl01", log.Remote)
+		assert.Equal(t, "Ethernet1/49", log.Interface)
+		assert.True(t, log.Down())
+		assert.True(t, result[0].Is(parselog.ISISLogType))
+	})
+	t.Run("sros base", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{
+			Messages:  []string{"vprn100 BGP-MINOR-tBgpBackwardTransition-2002 [Peer 1: 10.0.0.1]: Peer 1: 10.0.0.1: moved from higher state ESTABLISHED to lower state IDLE due to event HOLD TIME EXPIRED"},
+			Timestamp: time.Now(),
+			Platform:  "sros",
+			Source:    "pe01.sea01",
+		}
+		result, err := parselog.Parse(req)
+		require.NoError(t, err)
+		log, ok := result[0].(*types.BGPLog)
+		require.True(t, ok)
+		assert.Equal(t, req.Timestamp, log.Timestamp)
+		assert.Equal(t, "10.0.0.1", log.Remote)
+		assert.Equal(t, "vprn100", log.Table)
+		assert.True(t, log.Down())
+		assert.True(t, result[0].Is(parselog.BGPLogType))
+	})
+	t.Run("frr base", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{
+			Messages:  []string{"bgpd[700]: %ADJCHANGE: neighbor 10.0.0.1(rs-client-a) in vrf default Down Peer closed the session"},
+			Timestamp: time.Now(),
+			Platform:  "frr",
+			Source:    "rs01.sea01",
+		}
+		result, err := parselog.Parse(req)
+		require.NoError(t, err)
+		log, ok := result[0].(*types.BGPLog)
+		require.True(t, ok)
+		assert.Equal(t, req.Timestamp, log.Timestamp)
+		assert.Equal(t, "10.0.0.1", log.Remote)
+		assert.True(t, log.Down())
+		assert.True(t, result[0].Is(parselog.BGPLogType))
+	})
+	t.Run("bird base", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{
+			Messages:  []string{"bgp1: State changed to up"},
+			Timestamp: time.Now(),
+			Platform:  "bird",
+			Source:    "rs02.sea01",
+		}
+		result, err := parselog.Parse(req)
+		require.NoError(t, err)
+		log, ok := result[0].(*types.BGPLog)
+		require.True(t, ok)
+		assert.Equal(t, req.Timestamp, log.Timestamp)
+		assert.Equal(t, "bgp1", log.Remote)
+		assert.True(t, log.Up())
+		assert.True(t, result[0].Is(parselog.BGPLogType))
+	})
+	t.Run("huawei base", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{
+			Messages:  []string{"%%01BGP/2/hwBgpPeerBackwardTransition_active(l)[3]:OID 1.3.6.1.4.1.2011.5.25.177.1.3.2 The BGP FSM moves from a higher numbered state to a lower numbered state. (PeerRemoteAddr=10.1.1.2, BgpPeerUnavaiReason=Hold timer expired, VpnInstance=CUST-A)"},
+			Timestamp: time.Now(),
+			Platform:  "huawei_vrp",
+			Source:    "ne01.hkg01",
+		}
+		result, err := parselog.Parse(req)
+		require.NoError(t, err)
+		log, ok := result[0].(*types.BGPLog)
+		require.True(t, ok)
+		assert.Equal(t, req.Timestamp, log.Timestamp)
+		assert.Equal(t, "10.1.1.2", log.Remote)
+		assert.Equal(t, "CUST-A", log.Table)
+		assert.Equal(t, "Hold timer expired", log.Reason)
+		assert.True(t, log.Down())
+		assert.True(t, result[0].Is(parselog.BGPLogType))
+	})
 	t.Run("no matching platform", func(t *testing.T) {
 		t.Parallel()
 		req := &types.Request{Platform: "no-match"}
@@ -127,3 +254,108 @@ func Test_Parse(t *testing.T) {
 		assert.ErrorIs(t, err, types.ErrNoMatchingPlatform)
 	})
 }
+
+func Test_Register(t *testing.T) {
+	t.Run("custom platform", func(t *testing.T) {
+		t.Parallel()
+		parselog.Register("parse_test_custom", func(req *types.Request) ([]types.Log, error) {
+			return []types.Log{&types.ISISLog{Base: types.Base{Type: types.ISIS}, Local: req.Source, Timestamp: req.Timestamp}}, nil
+		})
+		t.Cleanup(func() { parselog.Unregister("parse_test_custom") })
+		req := &types.Request{Messages: []string{"anything"}, Platform: "parse_test_custom", Source: "custom01", Timestamp: time.Now()}
+		result, err := parselog.Parse(req)
+		require.NoError(t, err)
+		assert.True(t, result[0].Is(parselog.ISISLogType))
+		assert.Equal(t, "custom01", result[0].Attrs()["local"])
+	})
+	t.Run("built-in aliases", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{
+			Messages:  []string{"L2 Neighbor State Change for SystemID 1004.2550.1100 on Et5 to UP"},
+			Timestamp: time.Now(),
+			Platform:  "eos",
+			Source:    "leaf0401",
+		}
+		result, err := parselog.Parse(req)
+		require.NoError(t, err)
+		assert.True(t, result[0].Is(parselog.ISISLogType))
+	})
+	t.Run("platforms", func(t *testing.T) {
+		t.Parallel()
+		platforms := parselog.Platforms()
+		for _, platform := range []string{"junos", "arista_eos", "iosxr", "iosxe", "nxos", "sros", "frr", "bird", "huawei_vrp"} {
+			assert.Contains(t, platforms, platform)
+		}
+		assert.NotContains(t, platforms, "eos")
+	})
+}
+
+func Test_ParseAll(t *testing.T) {
+	t.Run("partial success", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{
+			Messages: []string{
+				"%BGP-3-NOTIFICATION: received from neighbor 10.0.0.1",
+				"L2 Neighbor State Change for SystemID 1004.2550.1100 on Et5 to UP",
+			},
+			Platform: "eos",
+			Source:   "leaf0401",
+		}
+		result, err := parselog.ParseAll(req)
+		require.NoError(t, err)
+		require.Len(t, result.Logs, 1)
+		assert.True(t, result.Logs[0].Is(parselog.ISISLogType))
+		require.Len(t, result.Failed(), 1)
+		assert.Equal(t, 0, result.Failed()[0].Index)
+		assert.Equal(t, "bgp_notification", result.Failed()[0].Pattern)
+		assert.ErrorIs(t, result.Err(), parselog.ErrIncompleteMatch)
+		var pe *types.ParseError
+		require.ErrorAs(t, result.Failed()[0].Err, &pe)
+		assert.Equal(t, "eos", pe.Platform)
+		assert.Equal(t, "leaf0401", pe.Source)
+		assert.Equal(t, "bgp_notification", pe.Pattern)
+		assert.Equal(t, req.Messages[0], pe.Message)
+		assert.Empty(t, pe.Missing)
+	})
+	t.Run("custom parser", func(t *testing.T) {
+		t.Parallel()
+		parselog.Register("parse_test_all", func(req *types.Request) ([]types.Log, error) {
+			if req.Messages[0] != "ok" {
+				return nil, types.ErrNoMatchingParser
+			}
+			return []types.Log{&types.ISISLog{Base: types.Base{Type: types.ISIS}}}, nil
+		})
+		t.Cleanup(func() { parselog.Unregister("parse_test_all") })
+		result, err := parselog.ParseAll(&types.Request{Messages: []string{"ok", "not ok"}, Platform: "parse_test_all"})
+		require.NoError(t, err)
+		assert.Len(t, result.Logs, 1)
+		assert.Len(t, result.Failed(), 1)
+	})
+	t.Run("no matching platform", func(t *testing.T) {
+		t.Parallel()
+		_, err := parselog.ParseAll(&types.Request{Messages: []string{"ok"}, Platform: "parse_test_missing"})
+		assert.ErrorIs(t, err, parselog.ErrNoMatchingPlatform)
+	})
+}
+
+func Test_Explain(t *testing.T) {
+	t.Run("captures", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{
+			Messages: []string{"BGP peer 10.0.0.2 (External AS 65000) changed state from Established to Idle (event RecvNotify) (instance master)"},
+			Platform: "junos",
+		}
+		result, err := parselog.Explain(req)
+		require.NoError(t, err)
+		require.Len(t, result.Logs, 1)
+		require.Len(t, result.Outcomes, 1)
+		assert.Equal(t, "bgp", result.Outcomes[0].Pattern)
+		assert.Equal(t, "10.0.0.2", result.Outcomes[0].Captures["remote"])
+		assert.Equal(t, "65000", result.Outcomes[0].Captures["asn"])
+	})
+	t.Run("unknown platform", func(t *testing.T) {
+		t.Parallel()
+		_, err := parselog.Explain(&types.Request{Messages: []string{"x"}, Platform: "nope"})
+		assert.ErrorIs(t, err, parselog.ErrNoMatchingPlatform)
+	})
+}
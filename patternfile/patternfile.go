@@ -0,0 +1,385 @@
+// Package patternfile compiles declarative YAML or JSON pattern definitions into parsers, so that
+// new messages can be supported without writing Go.
+//
+// A definition file names a platform and a list of patterns:
+//
+//	platform: acme_os
+//	patterns:
+//	  - name: bgp
+//	    match: '^BGP neighbor (?P<peer>\S+) AS (?P<remote_as>\d+) state (?P<state>\S+)$'
+//	    type: bgp
+//	    fields:
+//	      remote: peer
+//	    defaults:
+//	      table: default
+//	    states:
+//	      Established: up
+//	      Idle: down
+//	      Init: skip
+//	    required: [remote]
+//
+// Fields are keyed by the JSON name of the target log's field. Named groups that share a name with
+// a log field are mapped automatically; fields maps any others. The state field is resolved through
+// states, where each value is one of up, down or skip; skipped messages produce no log.
+package patternfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stellaraf/go-parselog/types"
+	"gopkg.in/yaml.v3"
+)
+
+var ErrInvalidDefinition = errors.New("invalid pattern definition")
+
+const (
+	stateUp   string = "up"
+	stateDown string = "down"
+	stateSkip string = "skip"
+)
+
+const fieldLocal string = "local"
+
+var (
+	stateType    = reflect.TypeOf(types.State(0))
+	bgpStateType = reflect.TypeOf(types.BGPState(0))
+)
+
+// Definition is the contents of a single pattern file.
+type Definition struct {
+	Platform string              `yaml:"platform" json:"platform"`
+	Patterns []PatternDefinition `yaml:"patterns" json:"patterns"`
+}
+
+// PatternDefinition describes how a single message format maps onto a log type.
+type PatternDefinition struct {
+	Name        string            `yaml:"name" json:"name"`
+	Match       string            `yaml:"match" json:"match"`
+	Type        string            `yaml:"type" json:"type"`
+	Priority    int               `yaml:"priority,omitempty" json:"priority,omitempty"`
+	StopOnMatch *bool             `yaml:"stop_on_match,omitempty" json:"stop_on_match,omitempty"`
+	Fields      map[string]string `yaml:"fields,omitempty" json:"fields,omitempty"`
+	Defaults    map[string]string `yaml:"defaults,omitempty" json:"defaults,omitempty"`
+	States      map[string]string `yaml:"states,omitempty" json:"states,omitempty"`
+	Required    []string          `yaml:"required,omitempty" json:"required,omitempty"`
+}
+
+// Load reads a definition from YAML or JSON.
+func Load(r io.Reader) (*Definition, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var def *Definition
+	if trimmed := bytes.TrimSpace(b); len(trimmed) != 0 && trimmed[0] == '{' {
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&def)
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(b))
+		dec.KnownFields(true)
+		err = dec.Decode(&def)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDefinition, err)
+	}
+	if def == nil || def.Platform == "" {
+		return nil, fmt.Errorf("%w: missing platform", ErrInvalidDefinition)
+	}
+	return def, nil
+}
+
+// LoadFile reads a definition from a YAML or JSON file.
+func LoadFile(path string) (*Definition, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
+
+// Compile compiles each pattern definition into a types.Pattern, in the order they are defined.
+func (d *Definition) Compile() ([]types.Pattern, error) {
+	patterns := make([]types.Pattern, 0, len(d.Patterns))
+	for i := range d.Patterns {
+		pattern, err := d.Patterns[i].compile()
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// Parser compiles the definition into a parser for its platform.
+func (d *Definition) Parser() (types.Parser, error) {
+	patterns, err := d.Compile()
+	if err != nil {
+		return nil, err
+	}
+	return types.NewPatterns(patterns...).Parse, nil
+}
+
+// Register compiles the definition and registers it as a platform, replacing any parser already
+// registered for the platform.
+func (d *Definition) Register() error {
+	patterns, err := d.Compile()
+	if err != nil {
+		return err
+	}
+	types.RegisterPatterns(d.Platform, types.NewPatterns(patterns...))
+	return nil
+}
+
+func (p *PatternDefinition) invalid(format string, args ...any) error {
+	return fmt.Errorf("%w: pattern '%s': %s", ErrInvalidDefinition, p.Name, fmt.Sprintf(format, args...))
+}
+
+func (p *PatternDefinition) compile() (types.Pattern, error) {
+	if p.Name == "" {
+		return types.Pattern{}, fmt.Errorf("%w: pattern is missing a name", ErrInvalidDefinition)
+	}
+	logType := types.ParseLogType(p.Type)
+	if logType == 0 {
+		return types.Pattern{}, p.invalid("unknown log type '%s'", p.Type)
+	}
+	re, err := regexp.Compile(p.Match)
+	if err != nil {
+		return types.Pattern{}, p.invalid("%s", err)
+	}
+	structType := reflect.TypeOf(newLog(logType)).Elem()
+	fields := fieldIndexes(structType)
+
+	// groups maps each log field to the index of the named group that populates it.
+	groups := make(map[string]int)
+	for _, name := range re.SubexpNames() {
+		if _, ok := fields[name]; ok && name != "" {
+			groups[name] = re.SubexpIndex(name)
+		}
+	}
+	for field, group := range p.Fields {
+		if _, ok := fields[field]; !ok {
+			return types.Pattern{}, p.invalid("unknown field '%s' for type '%s'", field, p.Type)
+		}
+		idx := re.SubexpIndex(group)
+		if idx < 0 {
+			return types.Pattern{}, p.invalid("match has no group named '%s'", group)
+		}
+		groups[field] = idx
+	}
+	for field := range p.Defaults {
+		if _, ok := fields[field]; !ok {
+			return types.Pattern{}, p.invalid("unknown field '%s' for type '%s'", field, p.Type)
+		}
+	}
+	for _, field := range p.Required {
+		_, grouped := groups[field]
+		_, defaulted := p.Defaults[field]
+		if !grouped && !defaulted && field != fieldLocal {
+			return types.Pattern{}, p.invalid("required field '%s' is not mapped", field)
+		}
+	}
+	// A log's state can't be left at its zero value, so it must come from a group or a default.
+	for field, idx := range fields {
+		_, grouped := groups[field]
+		_, defaulted := p.Defaults[field]
+		if structType.FieldByIndex(idx).Type == stateType && !grouped && !defaulted {
+			return types.Pattern{}, p.invalid("state field '%s' is not mapped", field)
+		}
+	}
+	states := make(map[string]string, len(p.States))
+	for raw, state := range p.States {
+		state = strings.ToLower(state)
+		if state != stateUp && state != stateDown && state != stateSkip {
+			return types.Pattern{}, p.invalid("state '%s' must be one of up, down or skip", state)
+		}
+		states[strings.ToLower(raw)] = state
+	}
+	stopOnMatch := true
+	if p.StopOnMatch != nil {
+		stopOnMatch = *p.StopOnMatch
+	}
+
+	c := &compiled{
+		pattern:  re,
+		logType:  logType,
+		fields:   fields,
+		groups:   groups,
+		defaults: p.Defaults,
+		states:   states,
+		required: p.Required,
+	}
+	return types.Pattern{
+		Name:        p.Name,
+		Match:       types.RegexpMatcher(re),
+		Parse:       c.parse,
+		Regexp:      re,
+		Priority:    p.Priority,
+		StopOnMatch: stopOnMatch,
+	}, nil
+}
+
+type compiled struct {
+	pattern  *regexp.Regexp
+	logType  types.LogType
+	fields   map[string][]int
+	groups   map[string]int
+	defaults map[string]string
+	states   map[string]string
+	required []string
+}
+
+func (c *compiled) parse(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	matches := c.pattern.FindStringSubmatch(msg)
+	if matches == nil {
+		return nil, types.IncompleteMatchErr(c.pattern, matches)
+	}
+	values := make(map[string]string, len(c.groups)+len(c.defaults)+1)
+	values[fieldLocal] = src
+	for field, value := range c.defaults {
+		values[field] = value
+	}
+	for field, idx := range c.groups {
+		if value := strings.TrimSpace(matches[idx]); value != "" {
+			values[field] = value
+		}
+	}
+	missing := make([]string, 0)
+	for _, field := range c.required {
+		if values[field] == "" {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) != 0 {
+		return nil, types.MissingGroupsErr(missing...)
+	}
+
+	l := newLog(c.logType)
+	v := reflect.ValueOf(l).Elem()
+	v.FieldByName("Base").Set(reflect.ValueOf(types.Base{Type: c.logType, Original: msg, Extra: extra}))
+	v.FieldByName("Timestamp").Set(reflect.ValueOf(ts))
+	for field, value := range values {
+		idx, ok := c.fields[field]
+		if !ok {
+			continue
+		}
+		target := v.FieldByIndex(idx)
+		if target.Type() == stateType {
+			state, ok := c.state(value)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s '%s'", types.ErrIncompleteMatch, field, value)
+			}
+			if state == stateSkip {
+				return nil, nil
+			}
+			if state == stateUp {
+				target.Set(reflect.ValueOf(types.UP))
+			} else {
+				target.Set(reflect.ValueOf(types.DOWN))
+			}
+			continue
+		}
+		if err := setField(target, value); err != nil {
+			return nil, fmt.Errorf("%w: %s '%s': %w", types.ErrIncompleteMatch, field, value, err)
+		}
+	}
+	return l, nil
+}
+
+// state resolves a raw state value through the pattern's state mapping. Without a mapping, only
+// the values up and down are accepted.
+func (c *compiled) state(value string) (string, bool) {
+	value = strings.ToLower(value)
+	if len(c.states) == 0 {
+		return value, value == stateUp || value == stateDown
+	}
+	state, ok := c.states[value]
+	return state, ok
+}
+
+func setField(target reflect.Value, value string) error {
+	if target.Type() == bgpStateType {
+		target.Set(reflect.ValueOf(types.ParseBGPState(value)))
+		return nil
+	}
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, 0)
+		if err != nil {
+			return err
+		}
+		target.SetUint(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		target.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported field kind '%s'", target.Kind())
+	}
+	return nil
+}
+
+// fieldIndexes maps the JSON name of each field of a log struct that can be populated from a
+// message to its index. The embedded Base and the timestamp are always set by the parser.
+func fieldIndexes(t reflect.Type) map[string][]int {
+	fields := make(map[string][]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous || !settable(f.Type) {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = f.Index
+	}
+	return fields
+}
+
+func settable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+func newLog(logType types.LogType) types.Log {
+	switch logType {
+	case types.ISIS:
+		return &types.ISISLog{}
+	case types.BGP:
+		return &types.BGPLog{}
+	case types.OSPF:
+		return &types.OSPFLog{}
+	case types.BFD:
+		return &types.BFDLog{}
+	case types.Interface:
+		return &types.InterfaceLog{}
+	case types.LDP:
+		return &types.LDPLog{}
+	case types.RSVP:
+		return &types.RSVPLog{}
+	case types.BGPNotification:
+		return &types.BGPNotificationLog{}
+	case types.BGPPrefixLimit:
+		return &types.BGPPrefixLimitLog{}
+	}
+	return nil
+}
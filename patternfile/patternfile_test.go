@@ -0,0 +1,187 @@
+package patternfile_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stellaraf/go-parselog/patternfile"
+	"github.com/stellaraf/go-parselog/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const definitionYAML = `
+platform: patternfile_test_yaml
+patterns:
+  - name: bgp
+    match: '^BGP neighbor (?P<peer>\S+) AS (?P<remote_as>\d+) state (?P<state>\S+)(?: fsm (?P<fsm_state>\S+))?$'
+    type: bgp
+    fields:
+      remote: peer
+    defaults:
+      table: default
+    states:
+      Established: up
+      Idle: down
+      Init: skip
+    required: [remote]
+  - name: limit
+    match: '^MAXPFX (?P<remote>\S+) (?P<count>\d+)/(?P<limit>\d+) (?P<torn_down>true|false)$'
+    type: bgp_prefix_limit
+`
+
+const definitionJSON = `{
+	"platform": "patternfile_test_json",
+	"patterns": [
+		{
+			"name": "isis",
+			"match": "^ISIS adjacency (?P<remote>\\S+) on (?P<interface>\\S+) (?P<state>up|down)$",
+			"type": "isis",
+			"priority": 10
+		}
+	]
+}`
+
+func Test_Load(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		t.Parallel()
+		def, err := patternfile.Load(strings.NewReader(definitionYAML))
+		require.NoError(t, err)
+		assert.Equal(t, "patternfile_test_yaml", def.Platform)
+		require.Len(t, def.Patterns, 2)
+		assert.Equal(t, "peer", def.Patterns[0].Fields["remote"])
+	})
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+		def, err := patternfile.Load(strings.NewReader(definitionJSON))
+		require.NoError(t, err)
+		assert.Equal(t, "patternfile_test_json", def.Platform)
+		require.Len(t, def.Patterns, 1)
+		assert.Equal(t, 10, def.Patterns[0].Priority)
+	})
+	t.Run("file", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "patterns.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(definitionYAML), 0o600))
+		def, err := patternfile.LoadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "patternfile_test_yaml", def.Platform)
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		t.Parallel()
+		_, err := patternfile.Load(strings.NewReader("platform: x\npaterns: []\n"))
+		assert.ErrorIs(t, err, patternfile.ErrInvalidDefinition)
+	})
+	t.Run("missing platform", func(t *testing.T) {
+		t.Parallel()
+		_, err := patternfile.Load(strings.NewReader("patterns: []\n"))
+		assert.ErrorIs(t, err, patternfile.ErrInvalidDefinition)
+	})
+}
+
+func Test_Compile(t *testing.T) {
+	invalid := map[string]patternfile.PatternDefinition{
+		"missing name":     {Match: `.*`, Type: "bgp"},
+		"unknown type":     {Name: "x", Match: `.*`, Type: "nope"},
+		"bad regex":        {Name: "x", Match: `(`, Type: "bgp"},
+		"unknown field":    {Name: "x", Match: `(?P<a>.*)`, Type: "bgp", Fields: map[string]string{"nope": "a"}},
+		"missing group":    {Name: "x", Match: `.*`, Type: "bgp", Fields: map[string]string{"remote": "a"}},
+		"unknown default":  {Name: "x", Match: `.*`, Type: "bgp", Defaults: map[string]string{"nope": "a"}},
+		"unmapped require": {Name: "x", Match: `.*`, Type: "bgp", Required: []string{"remote"}},
+		"invalid state":    {Name: "x", Match: `(?P<state>.*)`, Type: "bgp", States: map[string]string{"Established": "sideways"}},
+		"unmapped state":   {Name: "x", Match: `(?P<remote>.*)`, Type: "bgp"},
+	}
+	for name, pattern := range invalid {
+		pattern := pattern
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			def := &patternfile.Definition{Platform: "x", Patterns: []patternfile.PatternDefinition{pattern}}
+			_, err := def.Compile()
+			assert.ErrorIs(t, err, patternfile.ErrInvalidDefinition)
+		})
+	}
+}
+
+func Test_Parser(t *testing.T) {
+	def, err := patternfile.Load(strings.NewReader(definitionYAML))
+	require.NoError(t, err)
+	parser, err := def.Parser()
+	require.NoError(t, err)
+	now := time.Now()
+
+	t.Run("state up", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"BGP neighbor 10.0.0.1 AS 65000 state Established fsm Established"}, Source: "acme01", Timestamp: now}
+		result, err := parser(req)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		log, ok := result[0].(*types.BGPLog)
+		require.True(t, ok)
+		assert.Equal(t, "acme01", log.Local)
+		assert.Equal(t, "10.0.0.1", log.Remote)
+		assert.Equal(t, "65000", log.RemoteAS)
+		assert.Equal(t, "default", log.Table)
+		assert.Equal(t, types.ESTABLISHED, log.FSMState)
+		assert.Equal(t, now, log.Timestamp)
+		assert.Equal(t, req.Messages[0], log.Original)
+		assert.True(t, log.Up())
+	})
+	t.Run("state down", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"BGP neighbor 10.0.0.1 AS 65000 state idle"}, Source: "acme01", Timestamp: now}
+		result, err := parser(req)
+		require.NoError(t, err)
+		assert.True(t, result[0].Down())
+	})
+	t.Run("state skip", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"BGP neighbor 10.0.0.1 AS 65000 state Init"}, Source: "acme01", Timestamp: now}
+		_, err := parser(req)
+		assert.ErrorIs(t, err, types.ErrNoMatchingParser)
+	})
+	t.Run("unmapped state", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"BGP neighbor 10.0.0.1 AS 65000 state Active"}, Source: "acme01", Timestamp: now}
+		_, err := parser(req)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+		assert.ErrorContains(t, err, "state 'Active'")
+	})
+	t.Run("numeric and boolean fields", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"MAXPFX 10.0.0.1 101/100 true"}, Source: "acme01", Timestamp: now}
+		result, err := parser(req)
+		require.NoError(t, err)
+		log, ok := result[0].(*types.BGPPrefixLimitLog)
+		require.True(t, ok)
+		assert.Equal(t, uint(101), log.Count)
+		assert.Equal(t, uint(100), log.Limit)
+		assert.True(t, log.TornDown)
+		assert.True(t, log.Down())
+	})
+	t.Run("invalid numeric field", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"MAXPFX 10.0.0.1 99999999999999999999999/100 true"}, Source: "acme01", Timestamp: now}
+		_, err := parser(req)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+		assert.ErrorContains(t, err, "count '99999999999999999999999'")
+	})
+}
+
+func Test_Register(t *testing.T) {
+	def, err := patternfile.Load(strings.NewReader(definitionJSON))
+	require.NoError(t, err)
+	require.NoError(t, def.Register())
+	t.Cleanup(func() { types.Unregister(def.Platform) })
+	parser, ok := types.Lookup("patternfile_test_json")
+	require.True(t, ok)
+	result, err := parser(&types.Request{Messages: []string{"ISIS adjacency r2 on et-0/0/0 down"}, Source: "acme01"})
+	require.NoError(t, err)
+	log, ok := result[0].(*types.ISISLog)
+	require.True(t, ok)
+	assert.Equal(t, "r2", log.Remote)
+	assert.Equal(t, "et-0/0/0", log.Interface)
+	assert.True(t, log.Down())
+}
@@ -0,0 +1,63 @@
+package sros
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/stellaraf/go-parselog/types"
+)
+
+var patternISIS = regexp.MustCompile(`(?P<table>\S+) ISIS-[A-Z]+-\w+-\d+ \[[^\]]*\]: .*[Aa]djacency state change, level [12] adjacency to (?P<remote>\S+) on interface (?P<iface>\S+) is (?P<state>[A-Za-z]+)(?:, reason: (?P<reason>.+))?$`)
+var patternBGP = regexp.MustCompile(`(?P<table>\S+) BGP-[A-Z]+-tBgp\w+-\d+ \[Peer \d+: (?P<remote>[^\]\s]+)(?: AS (?P<remote_as>\d+))?\]: .*moved (?:from (?:higher|lower) state (?P<old_state>\S+) to (?:higher|lower) state (?P<state>\S+)|into (?P<established>established) state)(?: due to event (?P<reason>.+))?$`)
+
+// Platform is the name this package's parser is registered under.
+const Platform string = "sros"
+
+// Patterns are evaluated in order against each message, and may be extended or overridden.
+var Patterns = types.NewPatterns(
+	types.Pattern{Name: "isis", Match: types.RegexpMatcher(regexp.MustCompile(`ISIS-[A-Z]+-\w+-\d+ .*[Aa]djacency state change`)), Parse: ParseISIS, Regexp: patternISIS, StopOnMatch: true},
+	types.Pattern{Name: "bgp", Match: types.RegexpMatcher(regexp.MustCompile(`BGP-[A-Z]+-tBgp(BackwardTransition|Established)`)), Parse: ParseBGP, Regexp: patternBGP, StopOnMatch: true},
+)
+
+func ParseISIS(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	return types.ParseISISAdjacency(patternISIS, msg, src, ts, extra)
+}
+
+func ParseBGP(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+	groups, err := types.Groups(patternBGP, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	state := groups["state"]
+	if state == "" {
+		state = groups["established"]
+	}
+
+	l := &types.BGPLog{
+		Base:             types.Base{Type: types.BGP, Original: msg, Extra: extra},
+		Timestamp:        ts,
+		Local:            src,
+		Remote:           groups["remote"],
+		State:            types.DOWN,
+		RemoteAS:         groups["remote_as"],
+		Table:            groups["table"],
+		Reason:           groups["reason"],
+		FSMState:         types.ParseBGPState(state),
+		PreviousFSMState: types.ParseBGPState(groups["old_state"]),
+		Event:            groups["reason"],
+	}
+	if strings.Contains(strings.ToLower(state), "established") {
+		l.State = types.UP
+	}
+	return l, nil
+}
+
+func init() {
+	types.RegisterPatterns(Platform, Patterns)
+}
+
+func Parse(req *types.Request) ([]types.Log, error) {
+	return Patterns.Parse(req)
+}
@@ -0,0 +1,144 @@
+package sros_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellaraf/go-parselog/sros"
+	"github.com/stellaraf/go-parselog/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseISIS(t *testing.T) {
+	t.Run("up", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		msg := "Base ISIS-MINOR-tmnxIsisAdjacencyChange-2020 [VR 1 ISIS 0]: Adjacency state change, level 2 adjacency to er02.hnl01 on interface to-er02 is Up"
+		result, err := sros.ParseISIS(msg, "pe01.sea01", now, nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.UP, attrs["state"])
+		assert.Equal(t, "er02.hnl01", attrs["remote"])
+		assert.Equal(t, "to-er02", attrs["interface"])
+		assert.Empty(t, attrs["reason"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Down())
+		assert.True(t, result.Up())
+	})
+	t.Run("down", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		msg := "Base ISIS-MINOR-tmnxIsisAdjacencyChange-2020 [VR 1 ISIS 0]: Adjacency state change, level 2 adjacency to er02.hnl01 on interface to-er02 is Down, reason: Hold Time Expired"
+		result, err := sros.ParseISIS(msg, "pe01.sea01", now, nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.DOWN, attrs["state"])
+		assert.Equal(t, "er02.hnl01", attrs["remote"])
+		assert.Equal(t, "to-er02", attrs["interface"])
+		assert.Equal(t, "Hold Time Expired", attrs["reason"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Up())
+		assert.True(t, result.Down())
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := sros.ParseISIS("Base ISIS-MINOR-tmnxIsisAdjacencyChange-2020 [VR 1 ISIS 0]: Adjacency state change", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_ParseBGP(t *testing.T) {
+	t.Run("up", func(t *testing.T) {
+		t.Parallel()
+		msg := "Base BGP-MINOR-tBgpEstablished-2001 [Peer 1: 10.0.0.1 AS 65000]: Peer 1: 10.0.0.1: moved into established state"
+		result, err := sros.ParseBGP(msg, "pe01.sea01", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.UP, attrs["state"])
+		assert.Equal(t, "10.0.0.1", attrs["remote"])
+		assert.Equal(t, "65000", attrs["remote_as"])
+		assert.Equal(t, "Base", attrs["table"])
+		assert.Equal(t, types.ESTABLISHED, attrs["fsm_state"])
+		assert.Empty(t, attrs["reason"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Down())
+		assert.True(t, result.Up())
+	})
+	t.Run("down", func(t *testing.T) {
+		t.Parallel()
+		msg := "vprn100 BGP-MINOR-tBgpBackwardTransition-2002 [Peer 1: 2604:c0c0:3000::13e2]: Peer 1: 2604:c0c0:3000::13e2: moved from higher state ESTABLISHED to lower state IDLE due to event HOLD TIME EXPIRED"
+		result, err := sros.ParseBGP(msg, "pe01.sea01", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, types.DOWN, attrs["state"])
+		assert.Equal(t, "2604:c0c0:3000::13e2", attrs["remote"])
+		assert.Empty(t, attrs["remote_as"])
+		assert.Equal(t, "vprn100", attrs["table"])
+		assert.Equal(t, "HOLD TIME EXPIRED", attrs["reason"])
+		assert.Equal(t, types.IDLE, attrs["fsm_state"])
+		assert.Equal(t, types.ESTABLISHED, attrs["previous_fsm_state"])
+		assert.Equal(t, msg, attrs["original"])
+		assert.False(t, result.Up())
+		assert.True(t, result.Down())
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		_, err := sros.ParseBGP("Base BGP-MINOR-tBgpBackwardTransition-2002 [Peer 1: 10.0.0.1]", "", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_Parse(t *testing.T) {
+	t.Run("isis", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"Base ISIS-MINOR-tmnxIsisAdjacencyChange-2020 [VR 1 ISIS 0]: Adjacency state change, level 2 adjacency to er02.hnl01 on interface to-er02 is Up"}}
+		result, err := sros.Parse(req)
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+	t.Run("bgp", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"Base BGP-MINOR-tBgpEstablished-2001 [Peer 1: 10.0.0.1]: Peer 1: 10.0.0.1: moved into established state"}}
+		result, err := sros.Parse(req)
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+	t.Run("no match", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"this has no match"}}
+		result, err := sros.Parse(req)
+		assert.ErrorIs(t, err, types.ErrNoMatchingParser)
+		assert.Nil(t, result)
+	})
+	t.Run("with invalid", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{
+			Messages: []string{
+				"Base BGP-MINOR-tBgpEstablished-2001 [Peer 1: 10.0.0.1]: Peer 1: 10.0.0.1: moved into established state",
+				"Base BGP-MINOR-tBgpBackwardTransition-2002 invalid",
+			},
+		}
+		result, err := sros.Parse(req)
+		require.ErrorIs(t, err, types.ErrIncompleteMatch)
+		assert.Nil(t, result)
+	})
+}
+
+func Test_Explain(t *testing.T) {
+	fixtures := map[string]string{
+		"isis": "Base ISIS-MINOR-tmnxIsisAdjacencyChange-2020 [VR 1 ISIS 0]: Adjacency state change, level 2 adjacency to er02.hnl01 on interface to-er02 is Down, reason: Hold Time Expired",
+		"bgp":  "Base BGP-MINOR-tBgpEstablished-2001 [Peer 1: 10.0.0.1 AS 65000]: Peer 1: 10.0.0.1: moved into established state",
+	}
+	for _, pattern := range sros.Patterns.List() {
+		msg, ok := fixtures[pattern.Name]
+		if !assert.True(t, ok, "no fixture for pattern %s", pattern.Name) {
+			continue
+		}
+		result := sros.Patterns.Explain(&types.Request{Messages: []string{msg}})
+		require.Len(t, result.Outcomes, 1)
+		assert.Equal(t, pattern.Name, result.Outcomes[0].Pattern)
+		assert.NoError(t, result.Outcomes[0].Err, pattern.Name)
+		assert.NotEmpty(t, result.Outcomes[0].Captures, pattern.Name)
+	}
+}
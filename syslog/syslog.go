@@ -0,0 +1,282 @@
+// Package syslog parses raw RFC 5424 and RFC 3164 syslog lines into requests.
+package syslog
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stellaraf/go-parselog/types"
+)
+
+var ErrInvalidPriority = errors.New("syslog message has an invalid priority")
+
+var ErrInvalidFormat = errors.New("syslog message is not valid RFC 5424 or RFC 3164")
+
+// An RFC 3164 TAG is alphanumeric, though in practice program names also contain -_./.
+var patternTag = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9\-_./]*$`)
+
+// Format is the syslog format a message was parsed from.
+type Format uint
+
+const (
+	RFC3164 Format = iota + 1
+	RFC5424
+)
+
+// Extra keys set on requests created from a message, in addition to types.ExtraAppName.
+const (
+	ExtraFacility       string = "facility"
+	ExtraSeverity       string = "severity"
+	ExtraHostname       string = "hostname"
+	ExtraProcID         string = "procid"
+	ExtraMsgID          string = "msgid"
+	ExtraStructuredData string = "structured_data"
+)
+
+const (
+	nilValue   string = "-"
+	maxPRI     int    = 191
+	maxTag     int    = 32
+	stampLen   int    = len(time.Stamp)
+	byteOrder  string = "\ufeff"
+	rfc5424Ver string = "1"
+)
+
+// Message is a parsed syslog message. Fields that are absent, or the RFC 5424 nil value, are
+// left empty.
+type Message struct {
+	Format    Format    `json:"format"`
+	Facility  int       `json:"facility"`
+	Severity  int       `json:"severity"`
+	Timestamp time.Time `json:"timestamp"`
+	Hostname  string    `json:"hostname"`
+	AppName   string    `json:"app_name"`
+	ProcID    string    `json:"procid"`
+	MsgID     string    `json:"msgid"`
+	// StructuredData maps each SD-ID to its parameters.
+	StructuredData map[string]map[string]string `json:"structured_data"`
+	Message        string                       `json:"message"`
+}
+
+// Parse parses a raw syslog line, detecting whether it is RFC 5424 or RFC 3164.
+func Parse(line string) (*Message, error) {
+	line = strings.TrimRight(line, "\r\n")
+	pri, rest, err := parsePRI(line)
+	if err != nil {
+		return nil, err
+	}
+	if version, after, ok := strings.Cut(rest, " "); ok && version == rfc5424Ver {
+		return parse5424(pri, after)
+	}
+	return parse3164(pri, rest, time.Now())
+}
+
+// ParseRFC5424 parses a raw RFC 5424 syslog line.
+func ParseRFC5424(line string) (*Message, error) {
+	pri, rest, err := parsePRI(strings.TrimRight(line, "\r\n"))
+	if err != nil {
+		return nil, err
+	}
+	version, after, ok := strings.Cut(rest, " ")
+	if !ok || version != rfc5424Ver {
+		return nil, ErrInvalidFormat
+	}
+	return parse5424(pri, after)
+}
+
+// ParseRFC3164 parses a raw RFC 3164 syslog line. RFC 3164 timestamps have no year or time zone, so
+// the timestamp is taken to be in the UTC year of now, or the year before if it would otherwise
+// be more than a day in the future.
+func ParseRFC3164(line string, now time.Time) (*Message, error) {
+	pri, rest, err := parsePRI(strings.TrimRight(line, "\r\n"))
+	if err != nil {
+		return nil, err
+	}
+	return parse3164(pri, rest, now)
+}
+
+// Request converts the message to a request for a platform. The hostname becomes the source, and
+// the header fields and structured data are added to Extra. An empty platform can be detected with
+// parselog.Detect.
+func (m *Message) Request(platform string) *types.Request {
+	extra := map[string]any{
+		ExtraFacility: m.Facility,
+		ExtraSeverity: m.Severity,
+	}
+	set := func(key, value string) {
+		if value != "" {
+			extra[key] = value
+		}
+	}
+	set(ExtraHostname, m.Hostname)
+	set(types.ExtraAppName, m.AppName)
+	set(ExtraProcID, m.ProcID)
+	set(ExtraMsgID, m.MsgID)
+	if len(m.StructuredData) != 0 {
+		extra[ExtraStructuredData] = m.StructuredData
+	}
+	return &types.Request{
+		Messages:  []string{m.Message},
+		Platform:  platform,
+		Source:    m.Hostname,
+		Timestamp: m.Timestamp,
+		Extra:     extra,
+	}
+}
+
+func parsePRI(line string) (int, string, error) {
+	if !strings.HasPrefix(line, "<") {
+		return 0, "", ErrInvalidPriority
+	}
+	end := strings.IndexByte(line, '>')
+	if end < 2 || end > 4 {
+		return 0, "", ErrInvalidPriority
+	}
+	pri, err := strconv.Atoi(line[1:end])
+	if err != nil || pri < 0 || pri > maxPRI {
+		return 0, "", ErrInvalidPriority
+	}
+	return pri, line[end+1:], nil
+}
+
+func parse5424(pri int, rest string) (*Message, error) {
+	fields := strings.SplitN(rest, " ", 6)
+	if len(fields) < 6 {
+		return nil, ErrInvalidFormat
+	}
+	msg := &Message{
+		Format:   RFC5424,
+		Facility: pri / 8,
+		Severity: pri % 8,
+		Hostname: nilable(fields[1]),
+		AppName:  nilable(fields[2]),
+		ProcID:   nilable(fields[3]),
+		MsgID:    nilable(fields[4]),
+	}
+	if fields[0] != nilValue {
+		ts, err := time.Parse(time.RFC3339Nano, fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+		}
+		msg.Timestamp = ts
+	}
+	sd, text, err := ParseStructuredData(fields[5])
+	if err != nil {
+		return nil, err
+	}
+	msg.StructuredData = sd
+	msg.Message = strings.TrimPrefix(text, byteOrder)
+	return msg, nil
+}
+
+// ParseStructuredData parses the STRUCTURED-DATA of an RFC 5424 message, returning each SD-ID's
+// parameters along with the MSG that follows it. It is exported for parsers of platforms that
+// leave structured data in the message text, such as when it has been relayed as RFC 3164.
+func ParseStructuredData(s string) (map[string]map[string]string, string, error) {
+	if s == nilValue || strings.HasPrefix(s, nilValue+" ") {
+		return nil, strings.TrimPrefix(strings.TrimPrefix(s, nilValue), " "), nil
+	}
+	sd := make(map[string]map[string]string)
+	i := 0
+	for i < len(s) && s[i] == '[' {
+		i++
+		start := i
+		for i < len(s) && s[i] != ' ' && s[i] != ']' {
+			i++
+		}
+		if i >= len(s) {
+			return nil, "", ErrInvalidFormat
+		}
+		id := s[start:i]
+		params := make(map[string]string)
+		for i < len(s) && s[i] == ' ' {
+			i++
+			eq := strings.IndexByte(s[i:], '=')
+			if eq < 1 || i+eq+1 >= len(s) || s[i+eq+1] != '"' {
+				return nil, "", ErrInvalidFormat
+			}
+			name := s[i : i+eq]
+			i += eq + 2
+			var value strings.Builder
+			for i < len(s) && s[i] != '"' {
+				if s[i] == '\\' && i+1 < len(s) && (s[i+1] == '"' || s[i+1] == '\\' || s[i+1] == ']') {
+					i++
+				}
+				value.WriteByte(s[i])
+				i++
+			}
+			if i >= len(s) {
+				return nil, "", ErrInvalidFormat
+			}
+			i++
+			params[name] = value.String()
+		}
+		if i >= len(s) || s[i] != ']' {
+			return nil, "", ErrInvalidFormat
+		}
+		i++
+		sd[id] = params
+	}
+	if len(sd) == 0 {
+		return nil, "", ErrInvalidFormat
+	}
+	return sd, strings.TrimPrefix(s[i:], " "), nil
+}
+
+func parse3164(pri int, rest string, now time.Time) (*Message, error) {
+	if len(rest) < stampLen+1 || rest[stampLen] != ' ' {
+		return nil, ErrInvalidFormat
+	}
+	ts, err := time.Parse(time.Stamp, rest[:stampLen])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+	}
+	now = now.UTC()
+	ts = ts.AddDate(now.Year(), 0, 0)
+	if ts.After(now.AddDate(0, 0, 1)) {
+		ts = ts.AddDate(-1, 0, 0)
+	}
+	msg := &Message{
+		Format:    RFC3164,
+		Facility:  pri / 8,
+		Severity:  pri % 8,
+		Timestamp: ts,
+	}
+	hostname, content, ok := strings.Cut(rest[stampLen+1:], " ")
+	if !ok {
+		return nil, ErrInvalidFormat
+	}
+	msg.Hostname = hostname
+	msg.AppName, msg.ProcID, msg.Message = parseTag(content)
+	return msg, nil
+}
+
+// parseTag splits the content of an RFC 3164 message into its tag, optional process ID, and the
+// message itself. Content without a tag is returned as the message, including content that starts
+// with a word followed by a colon that isn't a tag, such as a Cisco %FACILITY-SEVERITY-MNEMONIC.
+func parseTag(content string) (string, string, string) {
+	tag, text, ok := strings.Cut(content, ":")
+	if !ok {
+		return "", "", content
+	}
+	var procID string
+	if open := strings.IndexByte(tag, '['); open > 0 && strings.HasSuffix(tag, "]") {
+		procID = tag[open+1 : len(tag)-1]
+		tag = tag[:open]
+	}
+	if len(tag) > maxTag || !patternTag.MatchString(tag) {
+		return "", "", content
+	}
+	return tag, procID, strings.TrimPrefix(text, " ")
+}
+
+func nilable(s string) string {
+	if s == nilValue {
+		return ""
+	}
+	return s
+}
@@ -0,0 +1,231 @@
+package syslog_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellaraf/go-parselog"
+	"github.com/stellaraf/go-parselog/syslog"
+	"github.com/stellaraf/go-parselog/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseRFC5424(t *testing.T) {
+	t.Run("junos", func(t *testing.T) {
+		t.Parallel()
+		line := `<28>1 2024-07-13T21:57:59.123Z er01.gvl01.as14525.net rpd 2217 BGP_PEER_STATE_CHANGED [junos@2636.1.1.1.2.29 peer-name="10.0.0.2" old-state="Established" new-state="Idle" event-name="RecvNotify" instance="master"] BGP peer 10.0.0.2 (External AS 65000) changed state from Established to Idle (event RecvNotify) (instance master)`
+		msg, err := syslog.ParseRFC5424(line)
+		require.NoError(t, err)
+		assert.Equal(t, syslog.RFC5424, msg.Format)
+		assert.Equal(t, 3, msg.Facility)
+		assert.Equal(t, 4, msg.Severity)
+		assert.Equal(t, time.Date(2024, 7, 13, 21, 57, 59, 123000000, time.UTC), msg.Timestamp)
+		assert.Equal(t, "er01.gvl01.as14525.net", msg.Hostname)
+		assert.Equal(t, "rpd", msg.AppName)
+		assert.Equal(t, "2217", msg.ProcID)
+		assert.Equal(t, "BGP_PEER_STATE_CHANGED", msg.MsgID)
+		assert.Equal(t, "Established", msg.StructuredData["junos@2636.1.1.1.2.29"]["old-state"])
+		assert.Equal(t, "BGP peer 10.0.0.2 (External AS 65000) changed state from Established to Idle (event RecvNotify) (instance master)", msg.Message)
+	})
+	t.Run("nil values", func(t *testing.T) {
+		t.Parallel()
+		msg, err := syslog.Parse("<165>1 - - - - - - \ufeffhello world")
+		require.NoError(t, err)
+		assert.True(t, msg.Timestamp.IsZero())
+		assert.Empty(t, msg.Hostname)
+		assert.Empty(t, msg.AppName)
+		assert.Nil(t, msg.StructuredData)
+		assert.Equal(t, "hello world", msg.Message)
+	})
+	t.Run("escaped structured data", func(t *testing.T) {
+		t.Parallel()
+		msg, err := syslog.Parse(`<165>1 2024-07-13T21:57:59+00:00 host app - ID47 [a@1 x="q\"uo\]te" y="\\"][b@2] message`)
+		require.NoError(t, err)
+		assert.Equal(t, `q"uo]te`, msg.StructuredData["a@1"]["x"])
+		assert.Equal(t, `\`, msg.StructuredData["a@1"]["y"])
+		assert.Contains(t, msg.StructuredData, "b@2")
+		assert.Equal(t, "message", msg.Message)
+	})
+	t.Run("no message", func(t *testing.T) {
+		t.Parallel()
+		msg, err := syslog.Parse(`<165>1 2024-07-13T21:57:59Z host app - - [a@1 x="y"]`)
+		require.NoError(t, err)
+		assert.Empty(t, msg.Message)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		for _, line := range []string{
+			`<165>1 2024-07-13T21:57:59Z host app`,
+			`<165>1 not-a-time host app - - - msg`,
+			`<165>1 2024-07-13T21:57:59Z host app - - [a@1 x="y" msg`,
+			`<165>1 2024-07-13T21:57:59Z host app - - [a@1 x=y] msg`,
+		} {
+			_, err := syslog.ParseRFC5424(line)
+			assert.ErrorIs(t, err, syslog.ErrInvalidFormat, line)
+		}
+		_, err := syslog.ParseRFC5424("<165>Jul 13 21:57:59 host app: msg")
+		assert.ErrorIs(t, err, syslog.ErrInvalidFormat)
+	})
+}
+
+func Test_ParseStructuredData(t *testing.T) {
+	t.Run("elements", func(t *testing.T) {
+		t.Parallel()
+		sd, text, err := syslog.ParseStructuredData(`[junos@2636 a="b" c="d"][meta@1 e="f\"g"] the message`)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]map[string]string{"junos@2636": {"a": "b", "c": "d"}, "meta@1": {"e": `f"g`}}, sd)
+		assert.Equal(t, "the message", text)
+	})
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+		sd, text, err := syslog.ParseStructuredData("- the message")
+		require.NoError(t, err)
+		assert.Nil(t, sd)
+		assert.Equal(t, "the message", text)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		for _, s := range []string{"", "the message", `[junos@2636 a="b"`, `[junos@2636 a=b]`} {
+			_, _, err := syslog.ParseStructuredData(s)
+			assert.ErrorIs(t, err, syslog.ErrInvalidFormat, s)
+		}
+	})
+}
+
+func Test_ParseRFC3164(t *testing.T) {
+	now := time.Date(2024, 7, 14, 0, 0, 0, 0, time.UTC)
+	t.Run("arista", func(t *testing.T) {
+		t.Parallel()
+		msg, err := syslog.ParseRFC3164("<187>Jul 13 21:57:59 leaf0401 Bgp: %BGP-3-NOTIFICATION: received from neighbor 10.0.0.1 (VRF default AS 65000) 4/0 (Hold Timer Expired) 0 bytes", now)
+		require.NoError(t, err)
+		assert.Equal(t, syslog.RFC3164, msg.Format)
+		assert.Equal(t, 23, msg.Facility)
+		assert.Equal(t, 3, msg.Severity)
+		assert.Equal(t, time.Date(2024, 7, 13, 21, 57, 59, 0, time.UTC), msg.Timestamp)
+		assert.Equal(t, "leaf0401", msg.Hostname)
+		assert.Equal(t, "Bgp", msg.AppName)
+		assert.Empty(t, msg.ProcID)
+		assert.Equal(t, "%BGP-3-NOTIFICATION: received from neighbor 10.0.0.1 (VRF default AS 65000) 4/0 (Hold Timer Expired) 0 bytes", msg.Message)
+	})
+	t.Run("procid", func(t *testing.T) {
+		t.Parallel()
+		msg, err := syslog.ParseRFC3164("<28>Jul  3 01:02:03 er01 rpd[2217]: BGP peer 10.0.0.2 (External AS 65000) changed state", now)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 7, 3, 1, 2, 3, 0, time.UTC), msg.Timestamp)
+		assert.Equal(t, "rpd", msg.AppName)
+		assert.Equal(t, "2217", msg.ProcID)
+		assert.Equal(t, "BGP peer 10.0.0.2 (External AS 65000) changed state", msg.Message)
+	})
+	t.Run("no tag", func(t *testing.T) {
+		t.Parallel()
+		msg, err := syslog.ParseRFC3164("<28>Jul 13 21:57:59 leaf0401 peer 2604:c0c0::1 (VRF default AS 65000) old state Idle new state Active", now)
+		require.NoError(t, err)
+		assert.Empty(t, msg.AppName)
+		assert.Equal(t, "peer 2604:c0c0::1 (VRF default AS 65000) old state Idle new state Active", msg.Message)
+	})
+	t.Run("cisco mnemonic", func(t *testing.T) {
+		t.Parallel()
+		msg, err := syslog.ParseRFC3164("<189>Jul 13 21:57:59 rtr01 %BGP-5-ADJCHANGE: neighbor 10.0.0.1 Up", now)
+		require.NoError(t, err)
+		assert.Empty(t, msg.AppName)
+		assert.Equal(t, "%BGP-5-ADJCHANGE: neighbor 10.0.0.1 Up", msg.Message)
+	})
+	t.Run("previous year", func(t *testing.T) {
+		t.Parallel()
+		msg, err := syslog.ParseRFC3164("<28>Dec 31 23:59:59 er01 rpd: msg", time.Date(2025, 1, 1, 0, 0, 10, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, 2024, msg.Timestamp.Year())
+	})
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		_, err := syslog.ParseRFC3164("<28>yesterday er01 rpd: msg", now)
+		assert.ErrorIs(t, err, syslog.ErrInvalidFormat)
+		_, err = syslog.ParseRFC3164("<28>Jul 13 21:57:59 er01", now)
+		assert.ErrorIs(t, err, syslog.ErrInvalidFormat)
+	})
+}
+
+func Test_Parse(t *testing.T) {
+	t.Run("invalid priority", func(t *testing.T) {
+		t.Parallel()
+		for _, line := range []string{"no priority", "<>1 - - - - - -", "<192>1 - - - - - -", "<abc>msg", "<12345>msg"} {
+			_, err := syslog.Parse(line)
+			assert.ErrorIs(t, err, syslog.ErrInvalidPriority, line)
+		}
+	})
+	t.Run("trailing newline", func(t *testing.T) {
+		t.Parallel()
+		msg, err := syslog.Parse("<28>1 2024-07-13T21:57:59Z er01 rpd - - - msg\r\n")
+		require.NoError(t, err)
+		assert.Equal(t, "msg", msg.Message)
+	})
+}
+
+func Test_Request(t *testing.T) {
+	msg, err := syslog.Parse(`<28>1 2024-07-13T21:57:59Z er01 rpd 2217 BGP_PEER_STATE_CHANGED [junos@2636 a="b"] BGP peer`)
+	require.NoError(t, err)
+	req := msg.Request("junos")
+	assert.Equal(t, []string{"BGP peer"}, req.Messages)
+	assert.Equal(t, "junos", req.Platform)
+	assert.Equal(t, "er01", req.Source)
+	assert.Equal(t, msg.Timestamp, req.Timestamp)
+	assert.Equal(t, "rpd", req.Extra[types.ExtraAppName])
+	assert.Equal(t, "2217", req.Extra[syslog.ExtraProcID])
+	assert.Equal(t, "BGP_PEER_STATE_CHANGED", req.Extra[syslog.ExtraMsgID])
+	assert.Equal(t, 3, req.Extra[syslog.ExtraFacility])
+	assert.Equal(t, 4, req.Extra[syslog.ExtraSeverity])
+	assert.Equal(t, map[string]map[string]string{"junos@2636": {"a": "b"}}, req.Extra[syslog.ExtraStructuredData])
+
+	bare, err := syslog.Parse("<28>1 - - - - - - msg")
+	require.NoError(t, err)
+	assert.NotContains(t, bare.Request("").Extra, syslog.ExtraHostname)
+}
+
+func Test_ParseJunos(t *testing.T) {
+	now := time.Date(2024, 7, 14, 0, 0, 0, 0, time.UTC)
+	t.Run("bgp", func(t *testing.T) {
+		t.Parallel()
+		msg, err := syslog.ParseRFC3164("<28>Jul 13 21:57:59 er01 rpd[1234]: BGP_PEER_STATE_CHANGED: BGP peer 10.0.0.2 (External AS 65000) changed state from Established to Idle (event RecvNotify) (instance master)", now)
+		require.NoError(t, err)
+		logs, err := parselog.Parse(msg.Request("junos"))
+		require.NoError(t, err)
+		require.Len(t, logs, 1)
+		assert.True(t, logs[0].Is(types.BGPLogType))
+		assert.Equal(t, "10.0.0.2", logs[0].Attrs()["remote"])
+		assert.Equal(t, "er01", logs[0].Attrs()["local"])
+	})
+	t.Run("isis", func(t *testing.T) {
+		t.Parallel()
+		msg, err := syslog.Parse("<28>Jul 13 21:57:59 er01 rpd[1234]: RPD_ISIS_ADJDOWN: IS-IS lost L2 adjacency to er02.hnl01.as14525.net on ae0.3613, reason: Aged out")
+		require.NoError(t, err)
+		logs, err := parselog.Parse(msg.Request("junos"))
+		require.NoError(t, err)
+		require.Len(t, logs, 1)
+		assert.True(t, logs[0].Is(types.ISISLogType))
+		assert.Equal(t, "er02.hnl01.as14525.net", logs[0].Attrs()["remote"])
+		assert.Equal(t, "Aged out", logs[0].Attrs()["reason"])
+		assert.True(t, logs[0].Down())
+	})
+}
+
+func Test_Detect(t *testing.T) {
+	msg, err := syslog.Parse("<187>Jul 13 21:57:59 leaf0401 Bgp: peer 10.0.0.1 (VRF default AS 65000) old state OpenConfirm event Established new state Established")
+	require.NoError(t, err)
+	result, err := parselog.Detect(msg.Request(""), parselog.DefaultDetectPolicy)
+	require.NoError(t, err)
+	assert.Equal(t, "arista_eos", result.Platform)
+	require.Len(t, result.Logs, 1)
+	assert.Equal(t, "leaf0401", result.Logs[0].Attrs()["local"])
+
+	t.Run("cisco", func(t *testing.T) {
+		msg, err := syslog.Parse("<189>Jul 13 21:57:59 rtr01 %BGP-5-ADJCHANGE: neighbor 10.0.0.1 vpn vrf CUST-A Down BGP Notification sent")
+		require.NoError(t, err)
+		result, err := parselog.Detect(msg.Request(""), parselog.DefaultDetectPolicy)
+		require.NoError(t, err)
+		assert.Equal(t, "iosxe", result.Platform)
+		require.Len(t, result.Logs, 1)
+		assert.Equal(t, "rtr01", result.Logs[0].Attrs()["local"])
+		assert.Equal(t, "10.0.0.1", result.Logs[0].Attrs()["remote"])
+	})
+}
@@ -0,0 +1,92 @@
+package types
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Groups returns the value of each named group of pattern in its match of msg, with surrounding
+// whitespace trimmed. Groups that didn't take part in the match are empty. It returns an
+// ErrIncompleteMatch error if pattern doesn't match msg.
+func Groups(pattern *regexp.Regexp, msg string) (map[string]string, error) {
+	matches := pattern.FindStringSubmatch(msg)
+	if matches == nil {
+		return nil, IncompleteMatchErr(pattern, matches)
+	}
+	groups := make(map[string]string, len(matches))
+	for i, name := range pattern.SubexpNames() {
+		if name != "" {
+			groups[name] = strings.TrimSpace(matches[i])
+		}
+	}
+	return groups, nil
+}
+
+// ParseISISAdjacency parses an IS-IS adjacency change with pattern, which has remote, iface and
+// state groups and may have a reason group, as described by ISISAdjacency.
+func ParseISISAdjacency(pattern *regexp.Regexp, msg, src string, ts time.Time, extra map[string]any) (Log, error) {
+	groups, err := Groups(pattern, msg)
+	if err != nil {
+		return nil, err
+	}
+	return ISISAdjacency(groups, msg, src, ts, extra), nil
+}
+
+// ISISAdjacency builds an IS-IS adjacency change from its remote, iface, state and reason fields.
+// The adjacency is up when its state is up, and down otherwise. Changes to an initializing state
+// are skipped by returning nil.
+func ISISAdjacency(fields map[string]string, msg, src string, ts time.Time, extra map[string]any) Log {
+	state := strings.ToLower(fields["state"])
+	if strings.Contains(state, "init") {
+		return nil
+	}
+
+	l := &ISISLog{
+		Base:      Base{Type: ISIS, Original: msg, Extra: extra},
+		Local:     src,
+		Timestamp: ts,
+		Remote:    fields["remote"],
+		Interface: fields["iface"],
+		State:     DOWN,
+		Reason:    fields["reason"],
+	}
+	if state == "up" {
+		l.State = UP
+	}
+	return l
+}
+
+// ParseBGPAdjacency parses a BGP session going up or down with pattern, which has remote and state
+// groups and may have reason, table and remote_as groups. The session is up, and Established, when
+// its state is up, and is otherwise down from Established. A session without a table is in
+// defaultTable.
+func ParseBGPAdjacency(pattern *regexp.Regexp, defaultTable string, msg, src string, ts time.Time, extra map[string]any) (Log, error) {
+	groups, err := Groups(pattern, msg)
+	if err != nil {
+		return nil, err
+	}
+	table := groups["table"]
+	if table == "" {
+		table = defaultTable
+	}
+
+	l := &BGPLog{
+		Base:      Base{Type: BGP, Original: msg, Extra: extra},
+		Timestamp: ts,
+		Local:     src,
+		Remote:    groups["remote"],
+		State:     DOWN,
+		RemoteAS:  groups["remote_as"],
+		Table:     table,
+		Reason:    groups["reason"],
+		FSMState:  IDLE,
+	}
+	if strings.ToLower(groups["state"]) == "up" {
+		l.State = UP
+		l.FSMState = ESTABLISHED
+	} else {
+		l.PreviousFSMState = ESTABLISHED
+	}
+	return l, nil
+}
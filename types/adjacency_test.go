@@ -0,0 +1,94 @@
+package types_test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stellaraf/go-parselog/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Groups(t *testing.T) {
+	pattern := regexp.MustCompile(`^peer (?P<remote>\S+) (?P<state>\S+)(?: reason (?P<reason>.+))?$`)
+	t.Run("match", func(t *testing.T) {
+		t.Parallel()
+		groups, err := types.Groups(pattern, "peer 10.0.0.1 up reason  configured ")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"remote": "10.0.0.1", "state": "up", "reason": "configured"}, groups)
+	})
+	t.Run("optional group", func(t *testing.T) {
+		t.Parallel()
+		groups, err := types.Groups(pattern, "peer 10.0.0.1 up")
+		require.NoError(t, err)
+		assert.Equal(t, "", groups["reason"])
+	})
+	t.Run("no match", func(t *testing.T) {
+		t.Parallel()
+		_, err := types.Groups(pattern, "peer 10.0.0.1")
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_ParseISISAdjacency(t *testing.T) {
+	pattern := regexp.MustCompile(`^adjacency to (?P<remote>\S+) on (?P<iface>\S+) (?P<state>\w+)(?:, (?P<reason>.+))?$`)
+	t.Run("up", func(t *testing.T) {
+		t.Parallel()
+		result, err := types.ParseISISAdjacency(pattern, "adjacency to er02 on et1 UP", "er01", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, "er02", attrs["remote"])
+		assert.Equal(t, "et1", attrs["interface"])
+		assert.Equal(t, "er01", attrs["local"])
+		assert.True(t, result.Up())
+	})
+	t.Run("down", func(t *testing.T) {
+		t.Parallel()
+		result, err := types.ParseISISAdjacency(pattern, "adjacency to er02 on et1 Down, hold time expired", "er01", time.Now(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "hold time expired", result.Attrs()["reason"])
+		assert.True(t, result.Down())
+	})
+	t.Run("initializing", func(t *testing.T) {
+		t.Parallel()
+		result, err := types.ParseISISAdjacency(pattern, "adjacency to er02 on et1 Initializing", "er01", time.Now(), nil)
+		require.NoError(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("no match", func(t *testing.T) {
+		t.Parallel()
+		_, err := types.ParseISISAdjacency(pattern, "adjacency to er02", "er01", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
+
+func Test_ParseBGPAdjacency(t *testing.T) {
+	pattern := regexp.MustCompile(`^neighbor (?P<remote>\S+)(?: vrf (?P<table>\S+))? (?P<state>Up|Down)(?: (?P<reason>.+))?$`)
+	t.Run("up", func(t *testing.T) {
+		t.Parallel()
+		result, err := types.ParseBGPAdjacency(pattern, "default", "neighbor 10.0.0.1 Up", "er01", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, "10.0.0.1", attrs["remote"])
+		assert.Equal(t, "default", attrs["table"])
+		assert.Equal(t, types.ESTABLISHED, attrs["fsm_state"])
+		assert.True(t, result.Up())
+	})
+	t.Run("down", func(t *testing.T) {
+		t.Parallel()
+		result, err := types.ParseBGPAdjacency(pattern, "default", "neighbor 10.0.0.1 vrf CUST-A Down peer closed the session", "er01", time.Now(), nil)
+		require.NoError(t, err)
+		attrs := result.Attrs()
+		assert.Equal(t, "CUST-A", attrs["table"])
+		assert.Equal(t, "peer closed the session", attrs["reason"])
+		assert.Equal(t, types.IDLE, attrs["fsm_state"])
+		assert.Equal(t, types.ESTABLISHED, attrs["previous_fsm_state"])
+		assert.True(t, result.Down())
+	})
+	t.Run("no match", func(t *testing.T) {
+		t.Parallel()
+		_, err := types.ParseBGPAdjacency(pattern, "default", "neighbor 10.0.0.1", "er01", time.Now(), nil)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+}
@@ -3,6 +3,10 @@ package types
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"sync"
 )
 
 var ErrNoMatchingParser = errors.New("message did not match any known pattern for parsing")
@@ -11,6 +15,8 @@ var ErrIncompleteMatch = errors.New("message did not conform to the expected for
 
 var ErrNoMatchingPlatform = errors.New("platform not supported")
 
+var ErrAmbiguousPlatform = errors.New("messages matched more than one platform")
+
 func MissingFieldErr(field string) error {
 	return fmt.Errorf("request is missing field '%s'", field)
 }
@@ -18,3 +24,120 @@ func MissingFieldErr(field string) error {
 func InvalidTypeErr(field string) error {
 	return fmt.Errorf("type of request field '%s' is invalid", field)
 }
+
+// ParseError describes a message that could not be parsed. It wraps ErrIncompleteMatch or
+// ErrNoMatchingParser, so it can still be compared with errors.Is.
+type ParseError struct {
+	Platform string
+	Source   string
+	// Index is the position of the message in its request.
+	Index   int
+	Message string
+	// Pattern is the name of the pattern that matched the message but failed to parse it.
+	Pattern string
+	// Missing are the named groups, or fields, that could not be captured from the message.
+	Missing []string
+	Err     error
+}
+
+func (e *ParseError) Error() string {
+	var b strings.Builder
+	if e.Platform != "" {
+		b.WriteString(e.Platform + ": ")
+	}
+	fmt.Fprintf(&b, "message %d", e.Index)
+	if e.Source != "" {
+		fmt.Fprintf(&b, " from '%s'", e.Source)
+	}
+	if e.Pattern != "" {
+		fmt.Fprintf(&b, " (pattern '%s')", e.Pattern)
+	}
+	fmt.Fprintf(&b, ": %s", e.Err)
+	if len(e.Missing) != 0 {
+		fmt.Fprintf(&b, "; missing %s", strings.Join(e.Missing, ", "))
+	}
+	return b.String()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// IncompleteMatchErr returns an ErrIncompleteMatch ParseError listing the required named groups of
+// pattern that matched empty. matches is the result of pattern.FindStringSubmatch, and is nil when
+// the message didn't match at all, in which case no groups are listed.
+func IncompleteMatchErr(pattern *regexp.Regexp, matches []string) error {
+	missing := make([]string, 0)
+	if matches == nil {
+		return &ParseError{Missing: missing, Err: ErrIncompleteMatch}
+	}
+	for _, name := range requiredGroups(pattern) {
+		if matches[pattern.SubexpIndex(name)] == "" {
+			missing = append(missing, name)
+		}
+	}
+	return &ParseError{Missing: missing, Err: ErrIncompleteMatch}
+}
+
+// MissingGroupsErr returns an ErrIncompleteMatch ParseError for values that a parser requires but
+// could not find in a message.
+func MissingGroupsErr(names ...string) error {
+	return &ParseError{Missing: names, Err: ErrIncompleteMatch}
+}
+
+// WithContext adds a message's context to err, wrapping it in a ParseError if it isn't one
+// already. Context already present on a ParseError is kept.
+func WithContext(err error, req *Request, index int, pattern string) error {
+	pe := &ParseError{Err: err}
+	var existing *ParseError
+	if errors.As(err, &existing) {
+		copied := *existing
+		pe = &copied
+	}
+	if pe.Platform == "" {
+		pe.Platform = req.Platform
+	}
+	if pe.Source == "" {
+		pe.Source = req.Source
+	}
+	if pe.Message == "" && index < len(req.Messages) {
+		pe.Index = index
+		pe.Message = req.Messages[index]
+	}
+	if pe.Pattern == "" {
+		pe.Pattern = pattern
+	}
+	return pe
+}
+
+var requiredGroupCache sync.Map
+
+// requiredGroups returns the named groups of a pattern that take part in every match, i.e. those
+// that aren't inside an optional, repeated or alternated expression.
+func requiredGroups(pattern *regexp.Regexp) []string {
+	if cached, ok := requiredGroupCache.Load(pattern); ok {
+		return cached.([]string)
+	}
+	required := make([]string, 0)
+	if re, err := syntax.Parse(pattern.String(), syntax.Perl); err == nil {
+		var walk func(re *syntax.Regexp, optional bool)
+		walk = func(re *syntax.Regexp, optional bool) {
+			switch re.Op {
+			case syntax.OpQuest, syntax.OpStar, syntax.OpAlternate:
+				optional = true
+			case syntax.OpRepeat:
+				optional = optional || re.Min == 0
+			case syntax.OpCapture:
+				if re.Name != "" && !optional {
+					required = append(required, re.Name)
+				}
+			}
+			for _, sub := range re.Sub {
+				walk(sub, optional)
+			}
+		}
+		walk(re, false)
+	}
+	requiredGroupCache.Store(pattern, required)
+	return required
+}
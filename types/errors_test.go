@@ -0,0 +1,69 @@
+package types_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/stellaraf/go-parselog/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseError(t *testing.T) {
+	pattern := regexp.MustCompile(`^peer (?P<remote>\S+) state (?P<state>\S*)(?: reason (?P<reason>.+))?(?:(?P<a>x)|(?P<b>y))?$`)
+	t.Run("no match", func(t *testing.T) {
+		t.Parallel()
+		err := types.IncompleteMatchErr(pattern, pattern.FindStringSubmatch("peer 10.0.0.1"))
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+		var pe *types.ParseError
+		require.ErrorAs(t, err, &pe)
+		assert.Empty(t, pe.Missing)
+	})
+	t.Run("empty group", func(t *testing.T) {
+		t.Parallel()
+		err := types.IncompleteMatchErr(pattern, pattern.FindStringSubmatch("peer 10.0.0.1 state "))
+		var pe *types.ParseError
+		require.ErrorAs(t, err, &pe)
+		assert.Equal(t, []string{"state"}, pe.Missing)
+	})
+	t.Run("missing groups", func(t *testing.T) {
+		t.Parallel()
+		err := types.MissingGroupsErr("remote")
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+		assert.False(t, errors.Is(err, types.ErrNoMatchingParser))
+	})
+	t.Run("context", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"first", "peer 10.0.0.1"}, Platform: "junos", Source: "er01"}
+		err := types.WithContext(types.MissingGroupsErr("state"), req, 1, "bgp")
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+		var pe *types.ParseError
+		require.ErrorAs(t, err, &pe)
+		assert.Equal(t, "junos", pe.Platform)
+		assert.Equal(t, "er01", pe.Source)
+		assert.Equal(t, 1, pe.Index)
+		assert.Equal(t, "peer 10.0.0.1", pe.Message)
+		assert.Equal(t, "bgp", pe.Pattern)
+		assert.Equal(t, []string{"state"}, pe.Missing)
+		assert.Equal(t, "junos: message 1 from 'er01' (pattern 'bgp'): message did not conform to the expected format for parsing; missing state", err.Error())
+	})
+	t.Run("context keeps existing", func(t *testing.T) {
+		t.Parallel()
+		req := &types.Request{Messages: []string{"first"}, Platform: "junos"}
+		original := &types.ParseError{Platform: "custom", Pattern: "inner", Err: types.ErrIncompleteMatch}
+		err := types.WithContext(original, req, 0, "outer")
+		var pe *types.ParseError
+		require.ErrorAs(t, err, &pe)
+		assert.Equal(t, "custom", pe.Platform)
+		assert.Equal(t, "inner", pe.Pattern)
+		assert.Equal(t, "first", pe.Message)
+		assert.Empty(t, original.Message)
+	})
+	t.Run("wraps other errors", func(t *testing.T) {
+		t.Parallel()
+		err := types.WithContext(types.ErrNoMatchingParser, &types.Request{Messages: []string{"x"}}, 0, "")
+		assert.ErrorIs(t, err, types.ErrNoMatchingParser)
+		assert.Equal(t, "message 0: message did not match any known pattern for parsing", err.Error())
+	})
+}
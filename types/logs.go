@@ -2,6 +2,8 @@ package types
 
 import (
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/stellaraf/go-utils"
@@ -9,6 +11,7 @@ import (
 
 type State uint
 type LogType uint
+type BGPState uint
 
 type Parser func(*Request) ([]Log, error)
 
@@ -17,14 +20,50 @@ const (
 	DOWN
 )
 
+// BGP FSM states, numbered as in RFC 4273's bgpPeerState.
+const (
+	IDLE BGPState = iota + 1
+	CONNECT
+	ACTIVE
+	OPENSENT
+	OPENCONFIRM
+	ESTABLISHED
+)
+
 const (
 	ISIS LogType = iota + 1
 	BGP
+	OSPF
+	BFD
+	Interface
+	LDP
+	RSVP
+	BGPNotification
+	BGPPrefixLimit
 )
 
+var logTypeNames = map[LogType]string{
+	ISIS:            "isis",
+	BGP:             "bgp",
+	OSPF:            "ospf",
+	BFD:             "bfd",
+	Interface:       "interface",
+	LDP:             "ldp",
+	RSVP:            "rsvp",
+	BGPNotification: "bgp_notification",
+	BGPPrefixLimit:  "bgp_prefix_limit",
+}
+
 var (
-	ISISLogType = &ISISLog{Base: Base{Type: ISIS}}
-	BGPLogType  = &BGPLog{Base: Base{Type: BGP}}
+	ISISLogType            = &ISISLog{Base: Base{Type: ISIS}}
+	BGPLogType             = &BGPLog{Base: Base{Type: BGP}}
+	OSPFLogType            = &OSPFLog{Base: Base{Type: OSPF}}
+	BFDLogType             = &BFDLog{Base: Base{Type: BFD}}
+	InterfaceLogType       = &InterfaceLog{Base: Base{Type: Interface}}
+	LDPLogType             = &LDPLog{Base: Base{Type: LDP}}
+	RSVPLogType            = &RSVPLog{Base: Base{Type: RSVP}}
+	BGPNotificationLogType = &BGPNotificationLog{Base: Base{Type: BGPNotification}}
+	BGPPrefixLimitLogType  = &BGPPrefixLimitLog{Base: Base{Type: BGPPrefixLimit}}
 )
 
 type Base struct {
@@ -53,13 +92,146 @@ type ISISLog struct {
 }
 
 type BGPLog struct {
+	Base
+	Local            string    `json:"local"`
+	Remote           string    `json:"remote"`
+	Timestamp        time.Time `json:"timestamp"`
+	State            State     `json:"state"`
+	RemoteAS         string    `json:"remote_as"`
+	Table            string    `json:"table"`
+	Reason           string    `json:"reason"`
+	FSMState         BGPState  `json:"fsm_state"`
+	PreviousFSMState BGPState  `json:"previous_fsm_state"`
+	Event            string    `json:"event"`
+}
+
+type BGPNotificationLog struct {
 	Base
 	Local     string    `json:"local"`
 	Remote    string    `json:"remote"`
 	Timestamp time.Time `json:"timestamp"`
-	State     State     `json:"state"`
 	RemoteAS  string    `json:"remote_as"`
 	Table     string    `json:"table"`
+	Received  bool      `json:"received"`
+	Code      uint      `json:"code"`
+	Subcode   uint      `json:"subcode"`
+	Text      string    `json:"text"`
+}
+
+type BGPPrefixLimitLog struct {
+	Base
+	Local     string    `json:"local"`
+	Remote    string    `json:"remote"`
+	Timestamp time.Time `json:"timestamp"`
+	RemoteAS  string    `json:"remote_as"`
+	Table     string    `json:"table"`
+	AFISAFI   string    `json:"afi_safi"`
+	Limit     uint      `json:"limit"`
+	Count     uint      `json:"count"`
+	Threshold bool      `json:"threshold"`
+	TornDown  bool      `json:"torn_down"`
+}
+
+type OSPFLog struct {
+	Base
+	Local     string    `json:"local"`
+	Remote    string    `json:"remote"`
+	Timestamp time.Time `json:"timestamp"`
+	State     State     `json:"state"`
+	Interface string    `json:"interface"`
+	Area      string    `json:"area"`
+	OldState  string    `json:"old_state"`
+	NewState  string    `json:"new_state"`
+	Reason    string    `json:"reason"`
+	Version   uint      `json:"version"`
+}
+
+type BFDLog struct {
+	Base
+	Local               string    `json:"local"`
+	Remote              string    `json:"remote"`
+	Timestamp           time.Time `json:"timestamp"`
+	State               State     `json:"state"`
+	Interface           string    `json:"interface"`
+	LocalDiscriminator  string    `json:"local_discriminator"`
+	RemoteDiscriminator string    `json:"remote_discriminator"`
+	Diagnostic          string    `json:"diagnostic"`
+}
+
+type InterfaceLog struct {
+	Base
+	Local       string    `json:"local"`
+	Timestamp   time.Time `json:"timestamp"`
+	State       State     `json:"state"`
+	Interface   string    `json:"interface"`
+	IfIndex     string    `json:"if_index"`
+	AdminStatus string    `json:"admin_status"`
+	OperStatus  string    `json:"oper_status"`
+	Parent      string    `json:"parent"`
+	Reason      string    `json:"reason"`
+}
+
+type LDPLog struct {
+	Base
+	Local     string    `json:"local"`
+	Remote    string    `json:"remote"`
+	Timestamp time.Time `json:"timestamp"`
+	State     State     `json:"state"`
+	Session   string    `json:"session"`
+	Reason    string    `json:"reason"`
+}
+
+type RSVPLog struct {
+	Base
+	Local     string    `json:"local"`
+	Timestamp time.Time `json:"timestamp"`
+	State     State     `json:"state"`
+	LSP       string    `json:"lsp"`
+	Path      string    `json:"path"`
+	Ingress   string    `json:"ingress"`
+	Egress    string    `json:"egress"`
+	Reason    string    `json:"reason"`
+}
+
+// ParseBGPState maps a vendor's rendering of a BGP FSM state, e.g. "OpenConfirm", "OPENCONFIRM" or
+// "open-confirm", to a BGPState. Unknown states are returned as 0.
+func ParseBGPState(s string) BGPState {
+	normalized := strings.ToLower(strings.NewReplacer(" ", "", "-", "", "_", "").Replace(s))
+	switch normalized {
+	case "idle":
+		return IDLE
+	case "connect":
+		return CONNECT
+	case "active":
+		return ACTIVE
+	case "opensent":
+		return OPENSENT
+	case "openconfirm":
+		return OPENCONFIRM
+	case "established":
+		return ESTABLISHED
+	}
+	return 0
+}
+
+// String returns the name of the log type, e.g. bgp_notification, or its number if it is unknown.
+func (t LogType) String() string {
+	if name, ok := logTypeNames[t]; ok {
+		return name
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
+// ParseLogType parses the name of a log type, as returned by LogType.String. Unknown names parse
+// to 0.
+func ParseLogType(s string) LogType {
+	s = strings.ToLower(s)
+	for t, name := range logTypeNames {
+		if name == s {
+			return t
+		}
+	}
+	return 0
 }
 
 // ISISLog Methods
@@ -125,13 +297,306 @@ func (l *BGPLog) Down() bool {
 }
 
 func (l *BGPLog) Attrs() map[string]any {
+	return map[string]any{
+		"local":              l.Local,
+		"remote":             l.Remote,
+		"timestamp":          l.Timestamp,
+		"state":              l.State,
+		"remote_as":          l.RemoteAS,
+		"table":              l.Table,
+		"reason":             l.Reason,
+		"fsm_state":          l.FSMState,
+		"previous_fsm_state": l.PreviousFSMState,
+		"event":              l.Event,
+		"type":               l.Type,
+		"extra":              l.Extra,
+		"original":           l.Original,
+	}
+}
+
+// OSPFLog Methods
+
+func (l *OSPFLog) Is(other Log) bool {
+	return other.LogType() == OSPF
+}
+
+func (l *OSPFLog) LogType() LogType {
+	return l.Type
+}
+
+func (l *OSPFLog) ID() string {
+	vars := []string{l.Local, l.Remote, l.Interface, strconv.FormatUint(uint64(l.Version), 10)}
+	sort.Strings(vars)
+	return utils.ShouldHashFromStrings(vars...)
+}
+
+func (l *OSPFLog) Up() bool {
+	return l.State == UP
+}
+
+func (l *OSPFLog) Down() bool {
+	return l.State == DOWN
+}
+
+func (l *OSPFLog) Attrs() map[string]any {
+	return map[string]any{
+		"local":     l.Local,
+		"remote":    l.Remote,
+		"timestamp": l.Timestamp,
+		"state":     l.State,
+		"interface": l.Interface,
+		"area":      l.Area,
+		"old_state": l.OldState,
+		"new_state": l.NewState,
+		"reason":    l.Reason,
+		"version":   l.Version,
+		"type":      l.Type,
+		"extra":     l.Extra,
+		"original":  l.Original,
+	}
+}
+
+// BFDLog Methods
+
+func (l *BFDLog) Is(other Log) bool {
+	return other.LogType() == BFD
+}
+
+func (l *BFDLog) LogType() LogType {
+	return l.Type
+}
+
+func (l *BFDLog) ID() string {
+	vars := []string{l.Local, l.Remote, l.Interface}
+	sort.Strings(vars)
+	return utils.ShouldHashFromStrings(vars...)
+}
+
+func (l *BFDLog) Up() bool {
+	return l.State == UP
+}
+
+func (l *BFDLog) Down() bool {
+	return l.State == DOWN
+}
+
+func (l *BFDLog) Attrs() map[string]any {
+	return map[string]any{
+		"local":                l.Local,
+		"remote":               l.Remote,
+		"timestamp":            l.Timestamp,
+		"state":                l.State,
+		"interface":            l.Interface,
+		"local_discriminator":  l.LocalDiscriminator,
+		"remote_discriminator": l.RemoteDiscriminator,
+		"diagnostic":           l.Diagnostic,
+		"type":                 l.Type,
+		"extra":                l.Extra,
+		"original":             l.Original,
+	}
+}
+
+// InterfaceLog Methods
+
+func (l *InterfaceLog) Is(other Log) bool {
+	return other.LogType() == Interface
+}
+
+func (l *InterfaceLog) LogType() LogType {
+	return l.Type
+}
+
+func (l *InterfaceLog) ID() string {
+	vars := []string{l.Local, l.Interface}
+	sort.Strings(vars)
+	return utils.ShouldHashFromStrings(vars...)
+}
+
+func (l *InterfaceLog) Up() bool {
+	return l.State == UP
+}
+
+func (l *InterfaceLog) Down() bool {
+	return l.State == DOWN
+}
+
+func (l *InterfaceLog) Attrs() map[string]any {
+	return map[string]any{
+		"local":        l.Local,
+		"timestamp":    l.Timestamp,
+		"state":        l.State,
+		"interface":    l.Interface,
+		"if_index":     l.IfIndex,
+		"admin_status": l.AdminStatus,
+		"oper_status":  l.OperStatus,
+		"parent":       l.Parent,
+		"reason":       l.Reason,
+		"type":         l.Type,
+		"extra":        l.Extra,
+		"original":     l.Original,
+	}
+}
+
+// LDPLog Methods
+
+func (l *LDPLog) Is(other Log) bool {
+	return other.LogType() == LDP
+}
+
+func (l *LDPLog) LogType() LogType {
+	return l.Type
+}
+
+func (l *LDPLog) ID() string {
+	vars := []string{l.Local, l.Remote}
+	sort.Strings(vars)
+	return utils.ShouldHashFromStrings(vars...)
+}
+
+func (l *LDPLog) Up() bool {
+	return l.State == UP
+}
+
+func (l *LDPLog) Down() bool {
+	return l.State == DOWN
+}
+
+func (l *LDPLog) Attrs() map[string]any {
 	return map[string]any{
 		"local":     l.Local,
 		"remote":    l.Remote,
 		"timestamp": l.Timestamp,
 		"state":     l.State,
+		"session":   l.Session,
+		"reason":    l.Reason,
+		"type":      l.Type,
+		"extra":     l.Extra,
+		"original":  l.Original,
+	}
+}
+
+// RSVPLog Methods
+
+func (l *RSVPLog) Is(other Log) bool {
+	return other.LogType() == RSVP
+}
+
+func (l *RSVPLog) LogType() LogType {
+	return l.Type
+}
+
+func (l *RSVPLog) ID() string {
+	vars := []string{l.Local, l.LSP, l.Ingress, l.Egress}
+	sort.Strings(vars)
+	return utils.ShouldHashFromStrings(vars...)
+}
+
+func (l *RSVPLog) Up() bool {
+	return l.State == UP
+}
+
+func (l *RSVPLog) Down() bool {
+	return l.State == DOWN
+}
+
+func (l *RSVPLog) Attrs() map[string]any {
+	return map[string]any{
+		"local":     l.Local,
+		"timestamp": l.Timestamp,
+		"state":     l.State,
+		"lsp":       l.LSP,
+		"path":      l.Path,
+		"ingress":   l.Ingress,
+		"egress":    l.Egress,
+		"reason":    l.Reason,
+		"type":      l.Type,
+		"extra":     l.Extra,
+		"original":  l.Original,
+	}
+}
+
+// BGPNotificationLog Methods
+
+func (l *BGPNotificationLog) Is(other Log) bool {
+	return other.LogType() == BGPNotification
+}
+
+func (l *BGPNotificationLog) LogType() LogType {
+	return l.Type
+}
+
+// ID is shared with the BGPLog of the same session.
+func (l *BGPNotificationLog) ID() string {
+	vars := []string{l.Local, l.Remote, l.RemoteAS, l.Table}
+	sort.Strings(vars)
+	return utils.ShouldHashFromStrings(vars...)
+}
+
+// Up is always false, as a NOTIFICATION always closes the session.
+func (l *BGPNotificationLog) Up() bool {
+	return false
+}
+
+func (l *BGPNotificationLog) Down() bool {
+	return true
+}
+
+func (l *BGPNotificationLog) Attrs() map[string]any {
+	return map[string]any{
+		"local":     l.Local,
+		"remote":    l.Remote,
+		"timestamp": l.Timestamp,
+		"remote_as": l.RemoteAS,
+		"table":     l.Table,
+		"received":  l.Received,
+		"code":      l.Code,
+		"subcode":   l.Subcode,
+		"text":      l.Text,
+		"type":      l.Type,
+		"extra":     l.Extra,
+		"original":  l.Original,
+	}
+}
+
+// BGPPrefixLimitLog Methods
+
+func (l *BGPPrefixLimitLog) Is(other Log) bool {
+	return other.LogType() == BGPPrefixLimit
+}
+
+func (l *BGPPrefixLimitLog) LogType() LogType {
+	return l.Type
+}
+
+// ID is shared with the BGPLog of the same session.
+func (l *BGPPrefixLimitLog) ID() string {
+	vars := []string{l.Local, l.Remote, l.RemoteAS, l.Table}
+	sort.Strings(vars)
+	return utils.ShouldHashFromStrings(vars...)
+}
+
+// Up is always false, as exceeding a prefix limit never brings a session up.
+func (l *BGPPrefixLimitLog) Up() bool {
+	return false
+}
+
+// Down is true only when the session was torn down as a result of the limit being exceeded.
+func (l *BGPPrefixLimitLog) Down() bool {
+	return l.TornDown
+}
+
+func (l *BGPPrefixLimitLog) Attrs() map[string]any {
+	return map[string]any{
+		"local":     l.Local,
+		"remote":    l.Remote,
+		"timestamp": l.Timestamp,
 		"remote_as": l.RemoteAS,
 		"table":     l.Table,
+		"afi_safi":  l.AFISAFI,
+		"limit":     l.Limit,
+		"count":     l.Count,
+		"threshold": l.Threshold,
+		"torn_down": l.TornDown,
 		"type":      l.Type,
 		"extra":     l.Extra,
 		"original":  l.Original,
@@ -21,6 +21,55 @@ func Test_Log(t *testing.T) {
 		assert.True(t, log.Is(types.BGPLogType))
 		assert.False(t, log.Is(types.ISISLogType))
 	})
+	t.Run("ospf is", func(t *testing.T) {
+		t.Parallel()
+		log := &types.OSPFLog{Base: types.Base{Type: types.OSPF}}
+		assert.True(t, log.Is(types.OSPFLogType))
+		assert.False(t, log.Is(types.ISISLogType))
+		assert.False(t, log.Is(types.BGPLogType))
+	})
+	t.Run("bfd is", func(t *testing.T) {
+		t.Parallel()
+		log := &types.BFDLog{Base: types.Base{Type: types.BFD}}
+		assert.True(t, log.Is(types.BFDLogType))
+		assert.False(t, log.Is(types.BGPLogType))
+	})
+	t.Run("interface is", func(t *testing.T) {
+		t.Parallel()
+		log := &types.InterfaceLog{Base: types.Base{Type: types.Interface}}
+		assert.True(t, log.Is(types.InterfaceLogType))
+		assert.False(t, log.Is(types.ISISLogType))
+	})
+	t.Run("ldp is", func(t *testing.T) {
+		t.Parallel()
+		log := &types.LDPLog{Base: types.Base{Type: types.LDP}}
+		assert.True(t, log.Is(types.LDPLogType))
+		assert.False(t, log.Is(types.RSVPLogType))
+	})
+	t.Run("rsvp is", func(t *testing.T) {
+		t.Parallel()
+		log := &types.RSVPLog{Base: types.Base{Type: types.RSVP}}
+		assert.True(t, log.Is(types.RSVPLogType))
+		assert.False(t, log.Is(types.LDPLogType))
+	})
+	t.Run("bgp notification is", func(t *testing.T) {
+		t.Parallel()
+		log := &types.BGPNotificationLog{Base: types.Base{Type: types.BGPNotification}}
+		assert.True(t, log.Is(types.BGPNotificationLogType))
+		assert.False(t, log.Is(types.BGPLogType))
+		assert.True(t, log.Down())
+		assert.False(t, log.Up())
+	})
+	t.Run("bgp prefix limit is", func(t *testing.T) {
+		t.Parallel()
+		log := &types.BGPPrefixLimitLog{Base: types.Base{Type: types.BGPPrefixLimit}}
+		assert.True(t, log.Is(types.BGPPrefixLimitLogType))
+		assert.False(t, log.Is(types.BGPLogType))
+		assert.False(t, log.Up())
+		assert.False(t, log.Down())
+		log.TornDown = true
+		assert.True(t, log.Down())
+	})
 	t.Run("isis up", func(t *testing.T) {
 		t.Parallel()
 		log := &types.ISISLog{Base: types.Base{Type: types.ISIS}, State: types.UP}
@@ -41,6 +90,54 @@ func Test_Log(t *testing.T) {
 		log := &types.BGPLog{Base: types.Base{Type: types.BGP}, State: types.DOWN}
 		assert.True(t, log.Down())
 	})
+	t.Run("ospf up", func(t *testing.T) {
+		t.Parallel()
+		log := &types.OSPFLog{Base: types.Base{Type: types.OSPF}, State: types.UP}
+		assert.True(t, log.Up())
+		assert.False(t, log.Down())
+	})
+	t.Run("ospf down", func(t *testing.T) {
+		t.Parallel()
+		log := &types.OSPFLog{Base: types.Base{Type: types.OSPF}, State: types.DOWN}
+		assert.True(t, log.Down())
+		assert.False(t, log.Up())
+	})
+	t.Run("bfd up", func(t *testing.T) {
+		t.Parallel()
+		log := &types.BFDLog{Base: types.Base{Type: types.BFD}, State: types.UP}
+		assert.True(t, log.Up())
+		assert.False(t, log.Down())
+	})
+	t.Run("bfd down", func(t *testing.T) {
+		t.Parallel()
+		log := &types.BFDLog{Base: types.Base{Type: types.BFD}, State: types.DOWN}
+		assert.True(t, log.Down())
+		assert.False(t, log.Up())
+	})
+	t.Run("interface up", func(t *testing.T) {
+		t.Parallel()
+		log := &types.InterfaceLog{Base: types.Base{Type: types.Interface}, State: types.UP}
+		assert.True(t, log.Up())
+		assert.False(t, log.Down())
+	})
+	t.Run("interface down", func(t *testing.T) {
+		t.Parallel()
+		log := &types.InterfaceLog{Base: types.Base{Type: types.Interface}, State: types.DOWN}
+		assert.True(t, log.Down())
+		assert.False(t, log.Up())
+	})
+	t.Run("ldp up", func(t *testing.T) {
+		t.Parallel()
+		log := &types.LDPLog{Base: types.Base{Type: types.LDP}, State: types.UP}
+		assert.True(t, log.Up())
+		assert.False(t, log.Down())
+	})
+	t.Run("rsvp down", func(t *testing.T) {
+		t.Parallel()
+		log := &types.RSVPLog{Base: types.Base{Type: types.RSVP}, State: types.DOWN}
+		assert.True(t, log.Down())
+		assert.False(t, log.Up())
+	})
 	t.Run("isis id", func(t *testing.T) {
 		t.Parallel()
 		log := &types.ISISLog{Base: types.Base{Type: types.ISIS}, Local: "local", Remote: "remote", Interface: "interface"}
@@ -51,6 +148,49 @@ func Test_Log(t *testing.T) {
 		log := &types.BGPLog{Base: types.Base{Type: types.BGP}, Local: "local", Remote: "remote", RemoteAS: "remote_as", Table: "table"}
 		assert.NotEmpty(t, log.ID())
 	})
+	t.Run("ospf id", func(t *testing.T) {
+		t.Parallel()
+		log := &types.OSPFLog{Base: types.Base{Type: types.OSPF}, Local: "local", Remote: "remote", Interface: "interface"}
+		assert.NotEmpty(t, log.ID())
+	})
+	t.Run("ospf id version", func(t *testing.T) {
+		t.Parallel()
+		v2 := &types.OSPFLog{Base: types.Base{Type: types.OSPF}, Local: "local", Remote: "10.0.0.2", Interface: "interface", Version: 2}
+		v3 := &types.OSPFLog{Base: types.Base{Type: types.OSPF}, Local: "local", Remote: "10.0.0.2", Interface: "interface", Version: 3}
+		assert.NotEqual(t, v2.ID(), v3.ID())
+	})
+	t.Run("bfd id", func(t *testing.T) {
+		t.Parallel()
+		log := &types.BFDLog{Base: types.Base{Type: types.BFD}, Local: "local", Remote: "remote", Interface: "interface"}
+		assert.NotEmpty(t, log.ID())
+	})
+	t.Run("interface id", func(t *testing.T) {
+		t.Parallel()
+		log := &types.InterfaceLog{Base: types.Base{Type: types.Interface}, Local: "local", Interface: "interface"}
+		assert.NotEmpty(t, log.ID())
+	})
+	t.Run("ldp id", func(t *testing.T) {
+		t.Parallel()
+		log := &types.LDPLog{Base: types.Base{Type: types.LDP}, Local: "local", Remote: "remote"}
+		assert.NotEmpty(t, log.ID())
+	})
+	t.Run("rsvp id", func(t *testing.T) {
+		t.Parallel()
+		log := &types.RSVPLog{Base: types.Base{Type: types.RSVP}, Local: "local", LSP: "lsp", Ingress: "ingress", Egress: "egress"}
+		assert.NotEmpty(t, log.ID())
+	})
+	t.Run("bgp notification id", func(t *testing.T) {
+		t.Parallel()
+		log := &types.BGPNotificationLog{Base: types.Base{Type: types.BGPNotification}, Local: "local", Remote: "remote", RemoteAS: "remote_as", Table: "table"}
+		session := &types.BGPLog{Base: types.Base{Type: types.BGP}, Local: "local", Remote: "remote", RemoteAS: "remote_as", Table: "table"}
+		assert.Equal(t, session.ID(), log.ID())
+	})
+	t.Run("bgp prefix limit id", func(t *testing.T) {
+		t.Parallel()
+		log := &types.BGPPrefixLimitLog{Base: types.Base{Type: types.BGPPrefixLimit}, Local: "local", Remote: "remote", RemoteAS: "remote_as", Table: "table", AFISAFI: "inet-unicast"}
+		session := &types.BGPLog{Base: types.Base{Type: types.BGP}, Local: "local", Remote: "remote", RemoteAS: "remote_as", Table: "table"}
+		assert.Equal(t, session.ID(), log.ID())
+	})
 	t.Run("isis attrs", func(t *testing.T) {
 		t.Parallel()
 		log := &types.ISISLog{Base: types.Base{Type: types.ISIS, Extra: nil, Original: "original"},
@@ -72,21 +212,238 @@ func Test_Log(t *testing.T) {
 	t.Run("bgp attrs", func(t *testing.T) {
 		t.Parallel()
 		log := &types.BGPLog{Base: types.Base{Type: types.ISIS, Extra: nil, Original: "original"},
+			Local:            "local",
+			Remote:           "remote",
+			RemoteAS:         "remote_as",
+			Timestamp:        time.Now(),
+			State:            types.UP,
+			Table:            "table",
+			Reason:           "reason",
+			FSMState:         types.IDLE,
+			PreviousFSMState: types.ESTABLISHED,
+			Event:            "RecvNotify",
+		}
+		attrs := log.Attrs()
+		assert.Equal(t, log.Local, attrs["local"])
+		assert.Equal(t, log.Remote, attrs["remote"])
+		assert.Equal(t, log.RemoteAS, attrs["remote_as"])
+		assert.Equal(t, log.Timestamp, attrs["timestamp"])
+		assert.Equal(t, log.State, attrs["state"])
+		assert.Equal(t, log.Type, attrs["type"])
+		assert.Equal(t, log.Extra, attrs["extra"])
+		assert.Equal(t, log.Table, attrs["table"])
+		assert.Equal(t, log.Reason, attrs["reason"])
+		assert.Equal(t, log.FSMState, attrs["fsm_state"])
+		assert.Equal(t, log.PreviousFSMState, attrs["previous_fsm_state"])
+		assert.Equal(t, log.Event, attrs["event"])
+	})
+	t.Run("ospf attrs", func(t *testing.T) {
+		t.Parallel()
+		log := &types.OSPFLog{Base: types.Base{Type: types.OSPF, Extra: nil, Original: "original"},
 			Local:     "local",
 			Remote:    "remote",
-			RemoteAS:  "remote_as",
+			Interface: "interface",
 			Timestamp: time.Now(),
-			State:     types.UP,
+			State:     types.DOWN,
+			Area:      "0.0.0.0",
+			OldState:  "Full",
+			NewState:  "Down",
+			Reason:    "reason",
+			Version:   2,
+		}
+		attrs := log.Attrs()
+		assert.Equal(t, log.Local, attrs["local"])
+		assert.Equal(t, log.Remote, attrs["remote"])
+		assert.Equal(t, log.Interface, attrs["interface"])
+		assert.Equal(t, log.Timestamp, attrs["timestamp"])
+		assert.Equal(t, log.State, attrs["state"])
+		assert.Equal(t, log.Area, attrs["area"])
+		assert.Equal(t, log.OldState, attrs["old_state"])
+		assert.Equal(t, log.NewState, attrs["new_state"])
+		assert.Equal(t, log.Reason, attrs["reason"])
+		assert.Equal(t, log.Version, attrs["version"])
+		assert.Equal(t, log.Type, attrs["type"])
+		assert.Equal(t, log.Extra, attrs["extra"])
+	})
+	t.Run("bfd attrs", func(t *testing.T) {
+		t.Parallel()
+		log := &types.BFDLog{Base: types.Base{Type: types.BFD, Extra: nil, Original: "original"},
+			Local:               "local",
+			Remote:              "remote",
+			Interface:           "interface",
+			Timestamp:           time.Now(),
+			State:               types.DOWN,
+			LocalDiscriminator:  "16",
+			RemoteDiscriminator: "17",
+			Diagnostic:          "CtlExpire",
+		}
+		attrs := log.Attrs()
+		assert.Equal(t, log.Local, attrs["local"])
+		assert.Equal(t, log.Remote, attrs["remote"])
+		assert.Equal(t, log.Interface, attrs["interface"])
+		assert.Equal(t, log.Timestamp, attrs["timestamp"])
+		assert.Equal(t, log.State, attrs["state"])
+		assert.Equal(t, log.LocalDiscriminator, attrs["local_discriminator"])
+		assert.Equal(t, log.RemoteDiscriminator, attrs["remote_discriminator"])
+		assert.Equal(t, log.Diagnostic, attrs["diagnostic"])
+		assert.Equal(t, log.Type, attrs["type"])
+		assert.Equal(t, log.Extra, attrs["extra"])
+	})
+	t.Run("interface attrs", func(t *testing.T) {
+		t.Parallel()
+		log := &types.InterfaceLog{Base: types.Base{Type: types.Interface, Extra: nil, Original: "original"},
+			Local:       "local",
+			Interface:   "interface",
+			Timestamp:   time.Now(),
+			State:       types.DOWN,
+			IfIndex:     "526",
+			AdminStatus: "up",
+			OperStatus:  "down",
+			Parent:      "ae0",
+			Reason:      "reason",
+		}
+		attrs := log.Attrs()
+		assert.Equal(t, log.Local, attrs["local"])
+		assert.Equal(t, log.Interface, attrs["interface"])
+		assert.Equal(t, log.Timestamp, attrs["timestamp"])
+		assert.Equal(t, log.State, attrs["state"])
+		assert.Equal(t, log.IfIndex, attrs["if_index"])
+		assert.Equal(t, log.AdminStatus, attrs["admin_status"])
+		assert.Equal(t, log.OperStatus, attrs["oper_status"])
+		assert.Equal(t, log.Parent, attrs["parent"])
+		assert.Equal(t, log.Reason, attrs["reason"])
+		assert.Equal(t, log.Type, attrs["type"])
+		assert.Equal(t, log.Extra, attrs["extra"])
+	})
+	t.Run("ldp attrs", func(t *testing.T) {
+		t.Parallel()
+		log := &types.LDPLog{Base: types.Base{Type: types.LDP, Extra: nil, Original: "original"},
+			Local:     "local",
+			Remote:    "remote",
+			Timestamp: time.Now(),
+			State:     types.DOWN,
+			Session:   "session",
+			Reason:    "reason",
+		}
+		attrs := log.Attrs()
+		assert.Equal(t, log.Local, attrs["local"])
+		assert.Equal(t, log.Remote, attrs["remote"])
+		assert.Equal(t, log.Timestamp, attrs["timestamp"])
+		assert.Equal(t, log.State, attrs["state"])
+		assert.Equal(t, log.Session, attrs["session"])
+		assert.Equal(t, log.Reason, attrs["reason"])
+		assert.Equal(t, log.Type, attrs["type"])
+		assert.Equal(t, log.Extra, attrs["extra"])
+	})
+	t.Run("rsvp attrs", func(t *testing.T) {
+		t.Parallel()
+		log := &types.RSVPLog{Base: types.Base{Type: types.RSVP, Extra: nil, Original: "original"},
+			Local:     "local",
+			Timestamp: time.Now(),
+			State:     types.DOWN,
+			LSP:       "lsp",
+			Path:      "path",
+			Ingress:   "ingress",
+			Egress:    "egress",
+			Reason:    "reason",
+		}
+		attrs := log.Attrs()
+		assert.Equal(t, log.Local, attrs["local"])
+		assert.Equal(t, log.Timestamp, attrs["timestamp"])
+		assert.Equal(t, log.State, attrs["state"])
+		assert.Equal(t, log.LSP, attrs["lsp"])
+		assert.Equal(t, log.Path, attrs["path"])
+		assert.Equal(t, log.Ingress, attrs["ingress"])
+		assert.Equal(t, log.Egress, attrs["egress"])
+		assert.Equal(t, log.Reason, attrs["reason"])
+		assert.Equal(t, log.Type, attrs["type"])
+		assert.Equal(t, log.Extra, attrs["extra"])
+	})
+	t.Run("bgp notification attrs", func(t *testing.T) {
+		t.Parallel()
+		log := &types.BGPNotificationLog{Base: types.Base{Type: types.BGPNotification, Extra: nil, Original: "original"},
+			Local:     "local",
+			Remote:    "remote",
+			RemoteAS:  "remote_as",
 			Table:     "table",
+			Timestamp: time.Now(),
+			Received:  true,
+			Code:      6,
+			Subcode:   2,
+			Text:      "Cease/Administrative Shutdown",
 		}
 		attrs := log.Attrs()
 		assert.Equal(t, log.Local, attrs["local"])
 		assert.Equal(t, log.Remote, attrs["remote"])
 		assert.Equal(t, log.RemoteAS, attrs["remote_as"])
+		assert.Equal(t, log.Table, attrs["table"])
 		assert.Equal(t, log.Timestamp, attrs["timestamp"])
-		assert.Equal(t, log.State, attrs["state"])
+		assert.Equal(t, log.Received, attrs["received"])
+		assert.Equal(t, log.Code, attrs["code"])
+		assert.Equal(t, log.Subcode, attrs["subcode"])
+		assert.Equal(t, log.Text, attrs["text"])
 		assert.Equal(t, log.Type, attrs["type"])
 		assert.Equal(t, log.Extra, attrs["extra"])
+	})
+	t.Run("bgp prefix limit attrs", func(t *testing.T) {
+		t.Parallel()
+		log := &types.BGPPrefixLimitLog{Base: types.Base{Type: types.BGPPrefixLimit, Extra: nil, Original: "original"},
+			Local:     "local",
+			Remote:    "remote",
+			RemoteAS:  "remote_as",
+			Table:     "table",
+			Timestamp: time.Now(),
+			AFISAFI:   "inet-unicast",
+			Limit:     100,
+			Count:     101,
+			TornDown:  true,
+		}
+		attrs := log.Attrs()
+		assert.Equal(t, log.Local, attrs["local"])
+		assert.Equal(t, log.Remote, attrs["remote"])
+		assert.Equal(t, log.RemoteAS, attrs["remote_as"])
 		assert.Equal(t, log.Table, attrs["table"])
+		assert.Equal(t, log.Timestamp, attrs["timestamp"])
+		assert.Equal(t, log.AFISAFI, attrs["afi_safi"])
+		assert.Equal(t, log.Limit, attrs["limit"])
+		assert.Equal(t, log.Count, attrs["count"])
+		assert.Equal(t, log.Threshold, attrs["threshold"])
+		assert.Equal(t, log.TornDown, attrs["torn_down"])
+		assert.Equal(t, log.Type, attrs["type"])
+		assert.Equal(t, log.Extra, attrs["extra"])
+	})
+}
+
+func Test_ParseBGPState(t *testing.T) {
+	t.Run("known", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, types.IDLE, types.ParseBGPState("Idle"))
+		assert.Equal(t, types.CONNECT, types.ParseBGPState("CONNECT"))
+		assert.Equal(t, types.ACTIVE, types.ParseBGPState("active"))
+		assert.Equal(t, types.OPENSENT, types.ParseBGPState("OpenSent"))
+		assert.Equal(t, types.OPENCONFIRM, types.ParseBGPState("open-confirm"))
+		assert.Equal(t, types.ESTABLISHED, types.ParseBGPState("ESTABLISHED"))
+	})
+	t.Run("unknown", func(t *testing.T) {
+		t.Parallel()
+		assert.Zero(t, types.ParseBGPState("Clearing"))
+		assert.Zero(t, types.ParseBGPState(""))
+	})
+}
+
+func Test_LogType(t *testing.T) {
+	t.Run("string", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, "bgp", types.BGP.String())
+		assert.Equal(t, "bgp_prefix_limit", types.BGPPrefixLimit.String())
+		assert.Equal(t, "42", types.LogType(42).String())
+	})
+	t.Run("parse", func(t *testing.T) {
+		t.Parallel()
+		for _, logType := range []types.LogType{types.ISIS, types.BGP, types.OSPF, types.BFD, types.Interface, types.LDP, types.RSVP, types.BGPNotification, types.BGPPrefixLimit} {
+			assert.Equal(t, logType, types.ParseLogType(logType.String()))
+		}
+		assert.Equal(t, types.ISIS, types.ParseLogType("ISIS"))
+		assert.Zero(t, types.ParseLogType("nope"))
 	})
 }
@@ -0,0 +1,225 @@
+package types
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Matcher reports whether a message should be handed to a pattern's parser.
+type Matcher func(msg string) bool
+
+// MessageParser parses a single message from a platform into a Log. A nil Log with a nil error
+// means the message was recognized but intentionally ignored.
+type MessageParser func(msg, src string, ts time.Time, extra map[string]any) (Log, error)
+
+// ExtraMatcher reports whether a message should be handed to a pattern's parser, given the
+// request's Extra as well as the message.
+type ExtraMatcher func(msg string, extra map[string]any) bool
+
+// Capturer returns the fields a pattern's parser extracts from a message, to explain how it was
+// parsed.
+type Capturer func(msg string, extra map[string]any) map[string]string
+
+// Pattern pairs a message matcher with the parser for the messages it matches.
+type Pattern struct {
+	// Name uniquely identifies the pattern within its platform.
+	Name  string
+	Match Matcher
+	// MatchExtra, when set, is used instead of Match for messages whose content is carried in the
+	// request's Extra, such as syslog structured data. Extra describes the whole request, so it is
+	// only passed to MatchExtra for requests with a single message; otherwise extra is nil.
+	MatchExtra ExtraMatcher
+	Parse      MessageParser
+	// Regexp, when set, is the expression Parse extracts named groups with. It is only used to
+	// explain how a message was parsed, and is best-effort: nothing ties it to what Parse does, so
+	// it should be the same expression and tested against the pattern's messages.
+	Regexp *regexp.Regexp
+	// Capture, when set, is used instead of Regexp to explain how a message was parsed, for
+	// parsers that extract fields other than with the named groups of a single expression.
+	Capture Capturer
+	// Priority orders evaluation; higher priorities are evaluated first and patterns of equal
+	// priority are evaluated in the order they were added.
+	Priority int
+	// StopOnMatch ends evaluation of a message once this pattern has matched it.
+	StopOnMatch bool
+}
+
+// Patterns is an ordered, concurrency-safe set of message patterns for a single platform.
+type Patterns struct {
+	mu       sync.RWMutex
+	patterns []Pattern
+	order    map[string]int
+	next     int
+}
+
+// PrefixMatcher matches messages beginning with prefix.
+func PrefixMatcher(prefix string) Matcher {
+	return func(msg string) bool {
+		return strings.HasPrefix(msg, prefix)
+	}
+}
+
+// RegexpMatcher matches messages matching pattern.
+func RegexpMatcher(pattern *regexp.Regexp) Matcher {
+	return pattern.MatchString
+}
+
+func NewPatterns(patterns ...Pattern) *Patterns {
+	p := &Patterns{order: make(map[string]int, len(patterns))}
+	for _, pattern := range patterns {
+		p.Add(pattern)
+	}
+	return p
+}
+
+// Add adds a pattern. Adding a pattern with the same name as an existing pattern replaces it,
+// keeping the existing pattern's position among patterns of equal priority.
+func (p *Patterns) Add(pattern Pattern) {
+	if pattern.Name == "" {
+		panic("parselog: pattern name is empty")
+	}
+	if (pattern.Match == nil && pattern.MatchExtra == nil) || pattern.Parse == nil {
+		panic("parselog: pattern " + pattern.Name + " is missing a matcher or parser")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.order[pattern.Name]; !ok {
+		p.order[pattern.Name] = p.next
+		p.next++
+	}
+	replaced := false
+	for i := range p.patterns {
+		if p.patterns[i].Name == pattern.Name {
+			p.patterns[i] = pattern
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		p.patterns = append(p.patterns, pattern)
+	}
+	sort.SliceStable(p.patterns, func(i, j int) bool {
+		if p.patterns[i].Priority != p.patterns[j].Priority {
+			return p.patterns[i].Priority > p.patterns[j].Priority
+		}
+		return p.order[p.patterns[i].Name] < p.order[p.patterns[j].Name]
+	})
+}
+
+// Remove removes the pattern with the given name, if it exists.
+func (p *Patterns) Remove(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := range p.patterns {
+		if p.patterns[i].Name == name {
+			p.patterns = append(p.patterns[:i], p.patterns[i+1:]...)
+			delete(p.order, name)
+			return
+		}
+	}
+}
+
+// List returns a copy of the patterns in evaluation order.
+func (p *Patterns) List() []Pattern {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	patterns := make([]Pattern, len(p.patterns))
+	copy(patterns, p.patterns)
+	return patterns
+}
+
+// Parse evaluates each message against the patterns in order. The first message that matches a
+// pattern but can't be parsed fails the whole request; use ParseAll to keep the other messages.
+func (p *Patterns) Parse(req *Request) ([]Log, error) {
+	return p.ParseAll(req).Unwrap()
+}
+
+// ParseAll evaluates each message against the patterns in order, recording the outcome of every
+// message rather than stopping at the first error.
+func (p *Patterns) ParseAll(req *Request) *Result {
+	return p.parseAll(req, false)
+}
+
+// Explain is ParseAll, with the fields each matching pattern's Capture or Regexp captured from its
+// message added to the message's outcome.
+func (p *Patterns) Explain(req *Request) *Result {
+	return p.parseAll(req, true)
+}
+
+func (p *Patterns) parseAll(req *Request, explain bool) *Result {
+	patterns := p.List()
+	result := &Result{
+		Logs:     make([]Log, 0, len(req.Messages)),
+		Outcomes: make([]Outcome, 0, len(req.Messages)),
+	}
+	// Extra can't be attributed to any one message of a request with several.
+	var matchExtra map[string]any
+	if len(req.Messages) == 1 {
+		matchExtra = req.Extra
+	}
+	for i, msg := range req.Messages {
+		outcome := Outcome{Index: i, Message: msg, Err: WithContext(ErrNoMatchingParser, req, i, "")}
+		for _, pattern := range patterns {
+			if !pattern.matches(msg, matchExtra) {
+				continue
+			}
+			if outcome.Pattern == "" {
+				outcome.Pattern = pattern.Name
+				outcome.Err = nil
+			}
+			if explain && outcome.Captures == nil {
+				outcome.Captures = pattern.captures(msg, matchExtra)
+			}
+			l, err := pattern.Parse(msg, req.Source, req.Timestamp, req.Extra)
+			if err != nil {
+				outcome.Pattern = pattern.Name
+				outcome.Err = WithContext(err, req, i, pattern.Name)
+				break
+			}
+			if l != nil {
+				result.Logs = append(result.Logs, l)
+			}
+			if pattern.StopOnMatch {
+				break
+			}
+		}
+		result.Outcomes = append(result.Outcomes, outcome)
+	}
+	return result
+}
+
+// Captures returns the value of each named group of re that participated in a match of msg. It
+// returns nil if re is nil or doesn't match.
+func Captures(re *regexp.Regexp, msg string) map[string]string {
+	if re == nil {
+		return nil
+	}
+	indexes := re.FindStringSubmatchIndex(msg)
+	if indexes == nil {
+		return nil
+	}
+	captures := make(map[string]string)
+	for i, name := range re.SubexpNames() {
+		if name != "" && indexes[2*i] >= 0 {
+			captures[name] = msg[indexes[2*i]:indexes[2*i+1]]
+		}
+	}
+	return captures
+}
+
+func (p *Pattern) captures(msg string, extra map[string]any) map[string]string {
+	if p.Capture != nil {
+		return p.Capture(msg, extra)
+	}
+	return Captures(p.Regexp, msg)
+}
+
+func (p *Pattern) matches(msg string, extra map[string]any) bool {
+	if p.MatchExtra != nil {
+		return p.MatchExtra(msg, extra)
+	}
+	return p.Match(msg)
+}
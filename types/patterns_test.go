@@ -0,0 +1,169 @@
+package types_test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stellaraf/go-parselog/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func namedParser(name string) types.MessageParser {
+	return func(msg, src string, ts time.Time, extra map[string]any) (types.Log, error) {
+		return &types.ISISLog{Base: types.Base{Type: types.ISIS, Original: msg}, Local: src, Reason: name}, nil
+	}
+}
+
+func reasons(logs []types.Log) []string {
+	out := make([]string, 0, len(logs))
+	for _, l := range logs {
+		out = append(out, l.Attrs()["reason"].(string))
+	}
+	return out
+}
+
+func Test_Patterns(t *testing.T) {
+	req := &types.Request{Messages: []string{"FOO: bar"}, Source: "source", Timestamp: time.Now()}
+	t.Run("insertion order", func(t *testing.T) {
+		t.Parallel()
+		p := types.NewPatterns(
+			types.Pattern{Name: "first", Match: types.PrefixMatcher("FOO"), Parse: namedParser("first")},
+			types.Pattern{Name: "second", Match: types.RegexpMatcher(regexp.MustCompile(`bar$`)), Parse: namedParser("second")},
+		)
+		result, err := p.Parse(req)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"first", "second"}, reasons(result))
+	})
+	t.Run("priority", func(t *testing.T) {
+		t.Parallel()
+		p := types.NewPatterns(
+			types.Pattern{Name: "first", Match: types.PrefixMatcher("FOO"), Parse: namedParser("first")},
+			types.Pattern{Name: "second", Match: types.PrefixMatcher("FOO"), Parse: namedParser("second"), Priority: 10},
+		)
+		result, err := p.Parse(req)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"second", "first"}, reasons(result))
+		assert.Equal(t, "second", p.List()[0].Name)
+	})
+	t.Run("stop on match", func(t *testing.T) {
+		t.Parallel()
+		p := types.NewPatterns(
+			types.Pattern{Name: "first", Match: types.PrefixMatcher("FOO"), Parse: namedParser("first"), StopOnMatch: true},
+			types.Pattern{Name: "second", Match: types.PrefixMatcher("FOO"), Parse: namedParser("second")},
+		)
+		result, err := p.Parse(req)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"first"}, reasons(result))
+	})
+	t.Run("override keeps position", func(t *testing.T) {
+		t.Parallel()
+		p := types.NewPatterns(
+			types.Pattern{Name: "first", Match: types.PrefixMatcher("FOO"), Parse: namedParser("first")},
+			types.Pattern{Name: "second", Match: types.PrefixMatcher("FOO"), Parse: namedParser("second")},
+		)
+		p.Add(types.Pattern{Name: "first", Match: types.PrefixMatcher("FOO"), Parse: namedParser("override")})
+		result, err := p.Parse(req)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"override", "second"}, reasons(result))
+	})
+	t.Run("remove", func(t *testing.T) {
+		t.Parallel()
+		p := types.NewPatterns(types.Pattern{Name: "first", Match: types.PrefixMatcher("FOO"), Parse: namedParser("first")})
+		p.Remove("first")
+		assert.Empty(t, p.List())
+		_, err := p.Parse(req)
+		assert.ErrorIs(t, err, types.ErrNoMatchingParser)
+	})
+	t.Run("ignored message", func(t *testing.T) {
+		t.Parallel()
+		p := types.NewPatterns(types.Pattern{Name: "ignore", Match: types.PrefixMatcher("FOO"), Parse: func(string, string, time.Time, map[string]any) (types.Log, error) {
+			return nil, nil
+		}})
+		_, err := p.Parse(req)
+		assert.ErrorIs(t, err, types.ErrNoMatchingParser)
+	})
+	t.Run("parser error", func(t *testing.T) {
+		t.Parallel()
+		p := types.NewPatterns(types.Pattern{Name: "fail", Match: types.PrefixMatcher("FOO"), Parse: func(string, string, time.Time, map[string]any) (types.Log, error) {
+			return nil, types.ErrIncompleteMatch
+		}})
+		_, err := p.Parse(req)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+	t.Run("parse all", func(t *testing.T) {
+		t.Parallel()
+		p := types.NewPatterns(
+			types.Pattern{Name: "good", Match: types.PrefixMatcher("GOOD"), Parse: namedParser("good"), StopOnMatch: true},
+			types.Pattern{Name: "bad", Match: types.PrefixMatcher("BAD"), Parse: func(string, string, time.Time, map[string]any) (types.Log, error) {
+				return nil, types.ErrIncompleteMatch
+			}},
+		)
+		req := &types.Request{Messages: []string{"GOOD: 1", "BAD: 2", "UNKNOWN: 3", "GOOD: 4"}}
+		result := p.ParseAll(req)
+		assert.Equal(t, []string{"good", "good"}, reasons(result.Logs))
+		require.Len(t, result.Outcomes, 4)
+		assert.Equal(t, types.Outcome{Index: 0, Message: "GOOD: 1", Pattern: "good"}, result.Outcomes[0])
+		assert.Equal(t, "bad", result.Outcomes[1].Pattern)
+		assert.ErrorIs(t, result.Outcomes[1].Err, types.ErrIncompleteMatch)
+		assert.Empty(t, result.Outcomes[2].Pattern)
+		assert.ErrorIs(t, result.Outcomes[2].Err, types.ErrNoMatchingParser)
+		var pe *types.ParseError
+		require.ErrorAs(t, result.Outcomes[1].Err, &pe)
+		assert.Equal(t, 1, pe.Index)
+		assert.Equal(t, "BAD: 2", pe.Message)
+		assert.Equal(t, "bad", pe.Pattern)
+		_, err := p.Parse(req)
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+	t.Run("explain", func(t *testing.T) {
+		t.Parallel()
+		re := regexp.MustCompile(`^(?P<key>\w+): (?P<value>\w+)(?: (?P<optional>\w+))?$`)
+		p := types.NewPatterns(
+			types.Pattern{Name: "first", Match: types.RegexpMatcher(re), Parse: namedParser("first"), Regexp: re},
+			types.Pattern{Name: "second", Match: types.PrefixMatcher("FOO"), Parse: namedParser("second")},
+		)
+		result := p.Explain(&types.Request{Messages: []string{"FOO: bar", "BAZ"}})
+		require.Len(t, result.Outcomes, 2)
+		assert.Equal(t, map[string]string{"key": "FOO", "value": "bar"}, result.Outcomes[0].Captures)
+		assert.Nil(t, result.Outcomes[1].Captures)
+		assert.Nil(t, p.ParseAll(req).Outcomes[0].Captures)
+		assert.Nil(t, types.Captures(nil, "FOO: bar"))
+	})
+	t.Run("match extra", func(t *testing.T) {
+		t.Parallel()
+		var matchExtra types.ExtraMatcher = func(msg string, extra map[string]any) bool {
+			return extra["event"] == "FOO"
+		}
+		p := types.NewPatterns(
+			types.Pattern{Name: "extra", MatchExtra: matchExtra, Parse: namedParser("extra"), Priority: 1, StopOnMatch: true},
+			types.Pattern{Name: "text", Match: types.PrefixMatcher("FOO"), Parse: namedParser("text")},
+		)
+		extra := map[string]any{"event": "FOO"}
+		single := p.ParseAll(&types.Request{Messages: []string{"FOO"}, Extra: extra})
+		assert.Equal(t, []string{"extra"}, reasons(single.Logs))
+		several := p.ParseAll(&types.Request{Messages: []string{"FOO", "BAR"}, Extra: extra})
+		assert.Equal(t, []string{"text"}, reasons(several.Logs))
+		assert.ErrorIs(t, several.Outcomes[1].Err, types.ErrNoMatchingParser)
+	})
+	t.Run("explain with capture", func(t *testing.T) {
+		t.Parallel()
+		re := regexp.MustCompile(`^(?P<key>\w+)`)
+		capture := func(msg string, extra map[string]any) map[string]string {
+			return map[string]string{"key": msg, "extra": extra["key"].(string)}
+		}
+		p := types.NewPatterns(
+			types.Pattern{Name: "first", Match: types.PrefixMatcher("FOO"), Parse: namedParser("first"), Regexp: re, Capture: capture},
+		)
+		result := p.Explain(&types.Request{Messages: []string{"FOO"}, Extra: map[string]any{"key": "value"}})
+		require.Len(t, result.Outcomes, 1)
+		assert.Equal(t, map[string]string{"key": "FOO", "extra": "value"}, result.Outcomes[0].Captures)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		p := types.NewPatterns()
+		assert.Panics(t, func() { p.Add(types.Pattern{Match: types.PrefixMatcher("FOO"), Parse: namedParser("")}) })
+		assert.Panics(t, func() { p.Add(types.Pattern{Name: "nil"}) })
+	})
+}
@@ -0,0 +1,155 @@
+package types
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+type registry struct {
+	mu       sync.RWMutex
+	parsers  map[string]Parser
+	patterns map[string]*Patterns
+	aliases  map[string]string
+	hints    map[string][]string
+}
+
+var platforms = &registry{
+	parsers:  make(map[string]Parser),
+	patterns: make(map[string]*Patterns),
+	aliases:  make(map[string]string),
+	hints:    make(map[string][]string),
+}
+
+// Register makes a parser available under the given platform name. Registering a platform that
+// already exists replaces its parser, which allows built-in parsers to be overridden.
+func Register(platform string, parser Parser) {
+	if platform == "" {
+		panic("parselog: Register called with empty platform")
+	}
+	if parser == nil {
+		panic("parselog: Register parser is nil for platform " + platform)
+	}
+	platforms.mu.Lock()
+	defer platforms.mu.Unlock()
+	register(platform, parser)
+}
+
+// register replaces a platform's parser, and must be called with the registry locked.
+func register(platform string, parser Parser) {
+	delete(platforms.aliases, platform)
+	delete(platforms.patterns, platform)
+	platforms.parsers[platform] = parser
+}
+
+// RegisterPatterns registers a platform whose parser is a set of patterns. Unlike a platform
+// registered with Register, its messages can be parsed with per-message outcomes.
+func RegisterPatterns(platform string, patterns *Patterns) {
+	if platform == "" {
+		panic("parselog: RegisterPatterns called with empty platform")
+	}
+	if patterns == nil {
+		panic("parselog: RegisterPatterns patterns are nil for platform " + platform)
+	}
+	platforms.mu.Lock()
+	defer platforms.mu.Unlock()
+	register(platform, patterns.Parse)
+	platforms.patterns[platform] = patterns
+}
+
+// RegisterAlias makes a platform available under an additional name. The alias resolves at lookup
+// time, so it follows the platform if its parser is replaced.
+func RegisterAlias(alias, platform string) {
+	platforms.mu.Lock()
+	defer platforms.mu.Unlock()
+	platforms.aliases[alias] = platform
+}
+
+// Unregister removes a platform, or an alias, from the registry. Removing a platform also removes
+// all of its aliases.
+func Unregister(platform string) {
+	platforms.mu.Lock()
+	defer platforms.mu.Unlock()
+	if _, ok := platforms.aliases[platform]; ok {
+		delete(platforms.aliases, platform)
+		return
+	}
+	delete(platforms.parsers, platform)
+	delete(platforms.patterns, platform)
+	delete(platforms.hints, platform)
+	for alias, target := range platforms.aliases {
+		if target == platform {
+			delete(platforms.aliases, alias)
+		}
+	}
+}
+
+// Lookup returns the parser registered for a platform name or alias.
+func Lookup(platform string) (Parser, bool) {
+	platforms.mu.RLock()
+	defer platforms.mu.RUnlock()
+	if target, ok := platforms.aliases[platform]; ok {
+		platform = target
+	}
+	parser, ok := platforms.parsers[platform]
+	return parser, ok
+}
+
+// LookupPatterns returns the patterns registered for a platform name or alias, if the platform was
+// registered with RegisterPatterns.
+func LookupPatterns(platform string) (*Patterns, bool) {
+	platforms.mu.RLock()
+	defer platforms.mu.RUnlock()
+	if target, ok := platforms.aliases[platform]; ok {
+		platform = target
+	}
+	patterns, ok := platforms.patterns[platform]
+	return patterns, ok
+}
+
+// Platforms returns the sorted names of all registered platforms, excluding aliases.
+func Platforms() []string {
+	platforms.mu.RLock()
+	defer platforms.mu.RUnlock()
+	names := make([]string, 0, len(platforms.parsers))
+	for name := range platforms.parsers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Aliases returns a copy of all registered aliases, mapped to the platform they resolve to.
+func Aliases() map[string]string {
+	platforms.mu.RLock()
+	defer platforms.mu.RUnlock()
+	aliases := make(map[string]string, len(platforms.aliases))
+	for alias, target := range platforms.aliases {
+		aliases[alias] = target
+	}
+	return aliases
+}
+
+// RegisterHints associates syslog app-names, such as rpd on Junos, with a platform. Hints are used
+// by platform detection to favor a platform when a message's app-name is known.
+func RegisterHints(platform string, appNames ...string) {
+	platforms.mu.Lock()
+	defer platforms.mu.Unlock()
+	for _, name := range appNames {
+		platforms.hints[platform] = append(platforms.hints[platform], strings.ToLower(name))
+	}
+}
+
+// HasHint reports whether appName is a registered hint for platform. The comparison is
+// case-insensitive.
+func HasHint(platform, appName string) bool {
+	platforms.mu.RLock()
+	defer platforms.mu.RUnlock()
+	appName = strings.ToLower(appName)
+	for _, hint := range platforms.hints[platform] {
+		if hint == appName {
+			return true
+		}
+	}
+	return false
+}
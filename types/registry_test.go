@@ -0,0 +1,105 @@
+package types_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stellaraf/go-parselog/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stubParser(req *types.Request) ([]types.Log, error) {
+	return []types.Log{&types.ISISLog{Base: types.Base{Type: types.ISIS}, Local: req.Source}}, nil
+}
+
+func Test_Registry(t *testing.T) {
+	t.Run("register and lookup", func(t *testing.T) {
+		t.Parallel()
+		types.Register("registry_test_lookup", stubParser)
+		t.Cleanup(func() { types.Unregister("registry_test_lookup") })
+		parser, ok := types.Lookup("registry_test_lookup")
+		require.True(t, ok)
+		result, err := parser(&types.Request{Source: "source"})
+		require.NoError(t, err)
+		assert.Equal(t, "source", result[0].Attrs()["local"])
+		assert.Contains(t, types.Platforms(), "registry_test_lookup")
+	})
+	t.Run("alias", func(t *testing.T) {
+		t.Parallel()
+		types.Register("registry_test_alias", stubParser)
+		types.RegisterAlias("registry_test_alias_alt", "registry_test_alias")
+		_, ok := types.Lookup("registry_test_alias_alt")
+		assert.True(t, ok)
+		assert.Equal(t, "registry_test_alias", types.Aliases()["registry_test_alias_alt"])
+		assert.NotContains(t, types.Platforms(), "registry_test_alias_alt")
+		types.Unregister("registry_test_alias")
+		_, ok = types.Lookup("registry_test_alias_alt")
+		assert.False(t, ok)
+		assert.NotContains(t, types.Aliases(), "registry_test_alias_alt")
+	})
+	t.Run("unregister alias only", func(t *testing.T) {
+		t.Parallel()
+		types.Register("registry_test_unalias", stubParser)
+		t.Cleanup(func() { types.Unregister("registry_test_unalias") })
+		types.RegisterAlias("registry_test_unalias_alt", "registry_test_unalias")
+		types.Unregister("registry_test_unalias_alt")
+		_, ok := types.Lookup("registry_test_unalias_alt")
+		assert.False(t, ok)
+		_, ok = types.Lookup("registry_test_unalias")
+		assert.True(t, ok)
+	})
+	t.Run("hints", func(t *testing.T) {
+		t.Parallel()
+		types.Register("registry_test_hints", stubParser)
+		types.RegisterHints("registry_test_hints", "Bgp", "rpd")
+		assert.True(t, types.HasHint("registry_test_hints", "bgp"))
+		assert.True(t, types.HasHint("registry_test_hints", "RPD"))
+		assert.False(t, types.HasHint("registry_test_hints", "isis"))
+		types.Unregister("registry_test_hints")
+		assert.False(t, types.HasHint("registry_test_hints", "rpd"))
+	})
+	t.Run("patterns", func(t *testing.T) {
+		t.Parallel()
+		patterns := types.NewPatterns()
+		types.RegisterPatterns("registry_test_patterns", patterns)
+		types.RegisterAlias("registry_test_patterns_alt", "registry_test_patterns")
+		t.Cleanup(func() { types.Unregister("registry_test_patterns") })
+		found, ok := types.LookupPatterns("registry_test_patterns_alt")
+		require.True(t, ok)
+		assert.Same(t, patterns, found)
+		_, ok = types.Lookup("registry_test_patterns")
+		assert.True(t, ok)
+		types.Register("registry_test_patterns", stubParser)
+		_, ok = types.LookupPatterns("registry_test_patterns")
+		assert.False(t, ok)
+	})
+	t.Run("missing", func(t *testing.T) {
+		t.Parallel()
+		_, ok := types.Lookup("registry_test_missing")
+		assert.False(t, ok)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		assert.Panics(t, func() { types.Register("", stubParser) })
+		assert.Panics(t, func() { types.Register("registry_test_nil", nil) })
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		t.Parallel()
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				name := fmt.Sprintf("registry_test_concurrent_%d", i)
+				types.Register(name, stubParser)
+				_, ok := types.Lookup(name)
+				assert.True(t, ok)
+				types.Platforms()
+				types.Unregister(name)
+			}(i)
+		}
+		wg.Wait()
+	})
+}
@@ -6,6 +6,9 @@ import (
 	"time"
 )
 
+// ExtraAppName is the Extra key holding the syslog app-name of a request's messages, if known.
+const ExtraAppName string = "app_name"
+
 type Request struct {
 	Messages  []string       `json:"message"`
 	Platform  string         `json:"platform"`
@@ -0,0 +1,104 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Outcome is the result of parsing a single message of a request.
+type Outcome struct {
+	// Index is the position of the message in the request.
+	Index   int
+	Message string
+	// Pattern is the name of the pattern that matched the message, if known.
+	Pattern string
+	Err     error
+	// Captures holds the named groups captured from the message by the matching pattern. It is
+	// only set by Patterns.Explain.
+	Captures map[string]string
+}
+
+// Result holds every log parsed from a request, along with the outcome of each message.
+type Result struct {
+	Logs     []Log     `json:"logs"`
+	Outcomes []Outcome `json:"outcomes"`
+}
+
+func (o Outcome) MarshalJSON() ([]byte, error) {
+	var errText string
+	if o.Err != nil {
+		errText = o.Err.Error()
+	}
+	fields := map[string]any{
+		"index":   o.Index,
+		"message": o.Message,
+		"pattern": o.Pattern,
+		"error":   errText,
+	}
+	if o.Captures != nil {
+		fields["captures"] = o.Captures
+	}
+	return json.Marshal(fields)
+}
+
+// Failed returns the outcomes of messages that could not be parsed.
+func (r *Result) Failed() []Outcome {
+	failed := make([]Outcome, 0)
+	for _, outcome := range r.Outcomes {
+		if outcome.Err != nil {
+			failed = append(failed, outcome)
+		}
+	}
+	return failed
+}
+
+// Err joins the errors of all messages that could not be parsed, or returns nil if every message
+// was parsed.
+func (r *Result) Err() error {
+	errs := make([]error, 0)
+	for _, outcome := range r.Failed() {
+		errs = append(errs, outcome.Err)
+	}
+	return errors.Join(errs...)
+}
+
+// Unwrap converts the result to the all-or-nothing return values of a Parser: the first error of a
+// message that matched but could not be parsed, otherwise the logs, or ErrNoMatchingParser if no
+// message produced a log.
+func (r *Result) Unwrap() ([]Log, error) {
+	for _, outcome := range r.Outcomes {
+		if outcome.Err != nil && !errors.Is(outcome.Err, ErrNoMatchingParser) {
+			return nil, outcome.Err
+		}
+	}
+	if len(r.Logs) != 0 {
+		return r.Logs, nil
+	}
+	return nil, ErrNoMatchingParser
+}
+
+// ParseEach builds a Result by calling parser with each message of a request on its own. It is
+// used for parsers that don't report per-message outcomes, so outcomes have no pattern name.
+func ParseEach(parser Parser, req *Request) *Result {
+	result := &Result{
+		Logs:     make([]Log, 0, len(req.Messages)),
+		Outcomes: make([]Outcome, 0, len(req.Messages)),
+	}
+	for i, msg := range req.Messages {
+		single := *req
+		single.Messages = []string{msg}
+		logs, err := parser(&single)
+		if err != nil {
+			err = WithContext(err, req, i, "")
+			// The parser only saw a single message, so any index it reported is relative to that.
+			var pe *ParseError
+			if errors.As(err, &pe) {
+				pe.Index = i
+				pe.Message = msg
+			}
+		}
+		result.Logs = append(result.Logs, logs...)
+		result.Outcomes = append(result.Outcomes, Outcome{Index: i, Message: msg, Err: err})
+	}
+	return result
+}
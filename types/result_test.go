@@ -0,0 +1,85 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stellaraf/go-parselog/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Result(t *testing.T) {
+	ok := &types.ISISLog{Base: types.Base{Type: types.ISIS}}
+	t.Run("unwrap logs", func(t *testing.T) {
+		t.Parallel()
+		result := &types.Result{
+			Logs:     []types.Log{ok},
+			Outcomes: []types.Outcome{{Index: 0, Pattern: "isis"}, {Index: 1, Err: types.ErrNoMatchingParser}},
+		}
+		logs, err := result.Unwrap()
+		require.NoError(t, err)
+		assert.Len(t, logs, 1)
+		assert.Len(t, result.Failed(), 1)
+		assert.ErrorIs(t, result.Err(), types.ErrNoMatchingParser)
+	})
+	t.Run("unwrap error", func(t *testing.T) {
+		t.Parallel()
+		result := &types.Result{
+			Logs:     []types.Log{ok},
+			Outcomes: []types.Outcome{{Index: 0, Pattern: "isis"}, {Index: 1, Pattern: "bgp", Err: types.ErrIncompleteMatch}},
+		}
+		_, err := result.Unwrap()
+		assert.ErrorIs(t, err, types.ErrIncompleteMatch)
+	})
+	t.Run("unwrap empty", func(t *testing.T) {
+		t.Parallel()
+		result := &types.Result{Outcomes: []types.Outcome{{Index: 0, Err: types.ErrNoMatchingParser}}}
+		_, err := result.Unwrap()
+		assert.ErrorIs(t, err, types.ErrNoMatchingParser)
+	})
+	t.Run("no errors", func(t *testing.T) {
+		t.Parallel()
+		result := &types.Result{Logs: []types.Log{ok}, Outcomes: []types.Outcome{{Index: 0, Pattern: "isis"}}}
+		assert.NoError(t, result.Err())
+		assert.Empty(t, result.Failed())
+	})
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+		b, err := json.Marshal(types.Outcome{Index: 1, Message: "msg", Pattern: "bgp", Err: types.ErrIncompleteMatch})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"index":1,"message":"msg","pattern":"bgp","error":"message did not conform to the expected format for parsing"}`, string(b))
+	})
+	t.Run("parse each", func(t *testing.T) {
+		t.Parallel()
+		parser := func(req *types.Request) ([]types.Log, error) {
+			if req.Messages[0] == "bad" {
+				return nil, types.ErrIncompleteMatch
+			}
+			return []types.Log{&types.ISISLog{Base: types.Base{Type: types.ISIS, Original: req.Messages[0]}}}, nil
+		}
+		req := &types.Request{Messages: []string{"good", "bad", "also good"}, Timestamp: time.Now()}
+		result := types.ParseEach(parser, req)
+		assert.Len(t, result.Logs, 2)
+		require.Len(t, result.Outcomes, 3)
+		assert.Equal(t, 1, result.Failed()[0].Index)
+		assert.Equal(t, "bad", result.Failed()[0].Message)
+	})
+	t.Run("parse each overrides inner index", func(t *testing.T) {
+		t.Parallel()
+		// Parsers built from patterns report errors with the index within the single-message
+		// request they were given.
+		parser := func(req *types.Request) ([]types.Log, error) {
+			return nil, types.WithContext(types.ErrIncompleteMatch, req, 0, "inner")
+		}
+		req := &types.Request{Messages: []string{"first", "second"}, Platform: "custom"}
+		result := types.ParseEach(parser, req)
+		require.Len(t, result.Failed(), 2)
+		var pe *types.ParseError
+		require.ErrorAs(t, result.Failed()[1].Err, &pe)
+		assert.Equal(t, 1, pe.Index)
+		assert.Equal(t, "second", pe.Message)
+		assert.Equal(t, "inner", pe.Pattern)
+	})
+}
@@ -0,0 +1,29 @@
+package types
+
+import "time"
+
+// Unmatched is a message that did not match any pattern of its platform.
+type Unmatched struct {
+	Platform  string         `json:"platform"`
+	Source    string         `json:"source"`
+	Timestamp time.Time      `json:"timestamp"`
+	Message   string         `json:"message"`
+	Extra     map[string]any `json:"extra"`
+}
+
+// UnmatchedSink receives messages that did not match any pattern, e.g. to find log lines that
+// aren't parsed yet. Implementations must be safe for concurrent use, and should not block.
+type UnmatchedSink interface {
+	Capture(Unmatched)
+}
+
+// NewUnmatched returns the Unmatched for the message at index of a request.
+func NewUnmatched(req *Request, index int) Unmatched {
+	return Unmatched{
+		Platform:  req.Platform,
+		Source:    req.Source,
+		Timestamp: req.Timestamp,
+		Message:   req.Messages[index],
+		Extra:     req.Extra,
+	}
+}
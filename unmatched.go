@@ -0,0 +1,51 @@
+package parselog
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/stellaraf/go-parselog/types"
+)
+
+var unmatchedSink struct {
+	mu   sync.RWMutex
+	sink types.UnmatchedSink
+}
+
+// SetUnmatchedSink sets the sink that receives every message Parse, ParseAll and Detect could not
+// match to a pattern. A nil sink disables capturing, which is the default.
+func SetUnmatchedSink(sink types.UnmatchedSink) {
+	unmatchedSink.mu.Lock()
+	defer unmatchedSink.mu.Unlock()
+	unmatchedSink.sink = sink
+}
+
+func currentUnmatchedSink() types.UnmatchedSink {
+	unmatchedSink.mu.RLock()
+	defer unmatchedSink.mu.RUnlock()
+	return unmatchedSink.sink
+}
+
+// captureUnmatched sends the messages of a result that matched no pattern to the sink.
+func captureUnmatched(request *Request, result *Result) {
+	sink := currentUnmatchedSink()
+	if sink == nil {
+		return
+	}
+	for _, outcome := range result.Outcomes {
+		if errors.Is(outcome.Err, types.ErrNoMatchingParser) {
+			sink.Capture(types.NewUnmatched(request, outcome.Index))
+		}
+	}
+}
+
+// captureAll sends every message of a request to the sink.
+func captureAll(request *Request) {
+	sink := currentUnmatchedSink()
+	if sink == nil {
+		return
+	}
+	for i := range request.Messages {
+		sink.Capture(types.NewUnmatched(request, i))
+	}
+}
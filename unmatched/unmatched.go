@@ -0,0 +1,76 @@
+// Package unmatched provides UnmatchedSink implementations for collecting messages that no
+// pattern matched.
+package unmatched
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/stellaraf/go-parselog/types"
+)
+
+// Memory keeps unmatched messages in memory. When a limit is set, only the most recent messages
+// are kept.
+type Memory struct {
+	mu       sync.Mutex
+	limit    int
+	messages []types.Unmatched
+}
+
+// NewMemory returns a Memory sink that keeps at most limit messages. A limit of 0 or less keeps
+// every message.
+func NewMemory(limit int) *Memory {
+	return &Memory{limit: limit, messages: make([]types.Unmatched, 0)}
+}
+
+func (m *Memory) Capture(u types.Unmatched) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, u)
+	if m.limit > 0 && len(m.messages) > m.limit {
+		m.messages = m.messages[len(m.messages)-m.limit:]
+	}
+}
+
+// Messages returns a copy of the captured messages, oldest first.
+func (m *Memory) Messages() []types.Unmatched {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	messages := make([]types.Unmatched, len(m.messages))
+	copy(messages, m.messages)
+	return messages
+}
+
+// Reset discards all captured messages.
+func (m *Memory) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = m.messages[:0]
+}
+
+// JSONL writes each unmatched message to a writer as a line of JSON.
+type JSONL struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	err error
+}
+
+func NewJSONL(w io.Writer) *JSONL {
+	return &JSONL{enc: json.NewEncoder(w)}
+}
+
+func (j *JSONL) Capture(u types.Unmatched) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if err := j.enc.Encode(u); err != nil && j.err == nil {
+		j.err = err
+	}
+}
+
+// Err returns the first error encountered while writing, if any.
+func (j *JSONL) Err() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.err
+}
@@ -0,0 +1,90 @@
+package unmatched_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stellaraf/go-parselog/types"
+	"github.com/stellaraf/go-parselog/unmatched"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func Test_Memory(t *testing.T) {
+	t.Run("capture", func(t *testing.T) {
+		t.Parallel()
+		sink := unmatched.NewMemory(0)
+		sink.Capture(types.Unmatched{Platform: "junos", Source: "er01", Message: "first"})
+		sink.Capture(types.Unmatched{Platform: "junos", Source: "er01", Message: "second"})
+		messages := sink.Messages()
+		require.Len(t, messages, 2)
+		assert.Equal(t, "first", messages[0].Message)
+		sink.Reset()
+		assert.Empty(t, sink.Messages())
+	})
+	t.Run("limit", func(t *testing.T) {
+		t.Parallel()
+		sink := unmatched.NewMemory(2)
+		for _, msg := range []string{"first", "second", "third"} {
+			sink.Capture(types.Unmatched{Message: msg})
+		}
+		messages := sink.Messages()
+		require.Len(t, messages, 2)
+		assert.Equal(t, "second", messages[0].Message)
+		assert.Equal(t, "third", messages[1].Message)
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		t.Parallel()
+		sink := unmatched.NewMemory(0)
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sink.Capture(types.Unmatched{Message: "msg"})
+			}()
+		}
+		wg.Wait()
+		assert.Len(t, sink.Messages(), 50)
+	})
+}
+
+func Test_JSONL(t *testing.T) {
+	t.Run("lines", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		sink := unmatched.NewJSONL(&buf)
+		now := time.Date(2024, 7, 13, 21, 57, 59, 0, time.UTC)
+		sink.Capture(types.Unmatched{Platform: "junos", Source: "er01", Timestamp: now, Message: "first"})
+		sink.Capture(types.Unmatched{Platform: "arista_eos", Source: "leaf01", Timestamp: now, Message: "second"})
+		require.NoError(t, sink.Err())
+		scanner := bufio.NewScanner(&buf)
+		lines := make([]types.Unmatched, 0)
+		for scanner.Scan() {
+			var u types.Unmatched
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &u))
+			lines = append(lines, u)
+		}
+		require.Len(t, lines, 2)
+		assert.Equal(t, "junos", lines[0].Platform)
+		assert.Equal(t, "leaf01", lines[1].Source)
+		assert.Equal(t, now, lines[1].Timestamp)
+	})
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		sink := unmatched.NewJSONL(failingWriter{})
+		sink.Capture(types.Unmatched{Message: "first"})
+		assert.Error(t, sink.Err())
+	})
+}
@@ -0,0 +1,77 @@
+package parselog_test
+
+import (
+	"testing"
+
+	"github.com/stellaraf/go-parselog"
+	"github.com/stellaraf/go-parselog/types"
+	"github.com/stellaraf/go-parselog/unmatched"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UnmatchedSink(t *testing.T) {
+	sink := unmatched.NewMemory(0)
+	parselog.SetUnmatchedSink(sink)
+	t.Cleanup(func() { parselog.SetUnmatchedSink(nil) })
+
+	t.Run("parse", func(t *testing.T) {
+		sink.Reset()
+		req := &types.Request{
+			Messages: []string{
+				"L2 Neighbor State Change for SystemID 1004.2550.1100 on Et5 to UP",
+				"%SOMETHING-5-NEW: not parsed yet",
+			},
+			Platform: "arista_eos",
+			Source:   "leaf0401",
+		}
+		_, err := parselog.Parse(req)
+		require.NoError(t, err)
+		messages := sink.Messages()
+		require.Len(t, messages, 1)
+		assert.Equal(t, "arista_eos", messages[0].Platform)
+		assert.Equal(t, "leaf0401", messages[0].Source)
+		assert.Equal(t, "%SOMETHING-5-NEW: not parsed yet", messages[0].Message)
+	})
+	t.Run("incomplete match is not captured", func(t *testing.T) {
+		sink.Reset()
+		req := &types.Request{Messages: []string{"%BGP-3-NOTIFICATION: received from neighbor 10.0.0.1"}, Platform: "arista_eos"}
+		result, err := parselog.ParseAll(req)
+		require.NoError(t, err)
+		assert.Len(t, result.Failed(), 1)
+		assert.Empty(t, sink.Messages())
+	})
+	t.Run("parse all", func(t *testing.T) {
+		sink.Reset()
+		req := &types.Request{Messages: []string{"unknown one", "unknown two"}, Platform: "junos", Source: "er01"}
+		_, err := parselog.ParseAll(req)
+		require.NoError(t, err)
+		assert.Len(t, sink.Messages(), 2)
+	})
+	t.Run("custom parser", func(t *testing.T) {
+		sink.Reset()
+		parselog.Register("unmatched_test_custom", func(req *types.Request) ([]types.Log, error) {
+			return nil, types.ErrNoMatchingParser
+		})
+		t.Cleanup(func() { parselog.Unregister("unmatched_test_custom") })
+		_, err := parselog.Parse(&types.Request{Messages: []string{"one", "two"}, Platform: "unmatched_test_custom"})
+		assert.ErrorIs(t, err, parselog.ErrNoMatchingParser)
+		assert.Len(t, sink.Messages(), 2)
+	})
+	t.Run("detect", func(t *testing.T) {
+		sink.Reset()
+		_, err := parselog.Detect(&types.Request{Messages: []string{"unknown vendor message"}, Source: "mystery01"}, parselog.DefaultDetectPolicy)
+		assert.ErrorIs(t, err, parselog.ErrNoMatchingPlatform)
+		messages := sink.Messages()
+		require.Len(t, messages, 1)
+		assert.Empty(t, messages[0].Platform)
+		assert.Equal(t, "mystery01", messages[0].Source)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		sink.Reset()
+		parselog.SetUnmatchedSink(nil)
+		defer parselog.SetUnmatchedSink(sink)
+		_, _ = parselog.ParseAll(&types.Request{Messages: []string{"unknown"}, Platform: "junos"})
+		assert.Empty(t, sink.Messages())
+	})
+}